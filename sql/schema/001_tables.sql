@@ -1,4 +1,5 @@
 CREATE TYPE role AS ENUM ('admin', 'user');
+CREATE TYPE job_status AS ENUM ('pending', 'running', 'completed', 'failed');
 
 CREATE TABLE IF NOT EXISTS users (
     id UUID PRIMARY KEY,
@@ -24,8 +25,82 @@ CREATE TABLE IF NOT EXISTS images_metadata (
     id UUID PRIMARY KEY,
     user_id UUID REFERENCES users(id) ON DELETE CASCADE,
     name VARCHAR(255) NOT NULL,
+    folder TEXT NOT NULL DEFAULT '/',
     description TEXT NOT NULL,
+    blurhash VARCHAR(64) NOT NULL DEFAULT '',
+    lqip TEXT NOT NULL DEFAULT '',
+    content_hash VARCHAR(64) NOT NULL DEFAULT '',
+    ahash BIGINT NOT NULL DEFAULT 0,
+    dhash BIGINT NOT NULL DEFAULT 0,
+    phash BIGINT NOT NULL DEFAULT 0,
+    palette JSONB NOT NULL DEFAULT '[]',
+    format VARCHAR(16) NOT NULL DEFAULT '',
+    size BIGINT NOT NULL DEFAULT 0,
+    width INT NOT NULL DEFAULT 0,
+    height INT NOT NULL DEFAULT 0,
+    search_vector TSVECTOR NOT NULL DEFAULT '',
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
-    CONSTRAINT unique_name_per_user UNIQUE (user_id, name)
+    CONSTRAINT unique_name_per_folder UNIQUE (user_id, folder, name)
+);
+
+CREATE TABLE IF NOT EXISTS images_jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    status job_status NOT NULL,
+    image_id UUID REFERENCES images_metadata(id) ON DELETE SET NULL,
+    source_image_id UUID REFERENCES images_metadata(id) ON DELETE SET NULL,
+    source_content_hash VARCHAR(64) NOT NULL DEFAULT '',
+    error TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS images_analyses (
+    image_id UUID PRIMARY KEY REFERENCES images_metadata(id) ON DELETE CASCADE,
+    content_hash VARCHAR(64) NOT NULL,
+    analysis JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_tag_per_user UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS images_tags (
+    image_id UUID REFERENCES images_metadata(id) ON DELETE CASCADE,
+    tag_id UUID REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (image_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS albums (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(128) NOT NULL,
+    description TEXT NOT NULL,
+    cover_image_id UUID REFERENCES images_metadata(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_album_name_per_user UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS albums_images (
+    album_id UUID REFERENCES albums(id) ON DELETE CASCADE,
+    image_id UUID REFERENCES images_metadata(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (album_id, image_id)
+);
+
+CREATE TABLE IF NOT EXISTS folders (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_folder_path_per_user UNIQUE (user_id, path)
 );
\ No newline at end of file
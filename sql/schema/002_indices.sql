@@ -1 +1,16 @@
-CREATE INDEX idx_images_user_id_name ON images_metadata(user_id, name);
\ No newline at end of file
+CREATE INDEX idx_images_user_id_name ON images_metadata(user_id, name);
+CREATE INDEX idx_images_user_id_content_hash ON images_metadata(user_id, content_hash);
+CREATE INDEX idx_images_user_id_phash_0 ON images_metadata(user_id, ((phash >> 48) & 65535));
+CREATE INDEX idx_images_user_id_phash_1 ON images_metadata(user_id, ((phash >> 32) & 65535));
+CREATE INDEX idx_images_user_id_phash_2 ON images_metadata(user_id, ((phash >> 16) & 65535));
+CREATE INDEX idx_images_user_id_phash_3 ON images_metadata(user_id, (phash & 65535));
+CREATE INDEX idx_images_jobs_user_id ON images_jobs(user_id);
+CREATE INDEX idx_images_jobs_source_image_id ON images_jobs(source_image_id, source_content_hash);
+CREATE INDEX idx_images_tags_tag_id ON images_tags(tag_id);
+CREATE INDEX idx_images_search_vector ON images_metadata USING GIN(search_vector);
+CREATE INDEX idx_images_user_id_created_at ON images_metadata(user_id, created_at, id);
+CREATE INDEX idx_images_user_id_updated_at ON images_metadata(user_id, updated_at, id);
+CREATE INDEX idx_images_user_id_size ON images_metadata(user_id, size, id);
+CREATE INDEX idx_images_user_id_dimensions ON images_metadata(user_id, (width::BIGINT * height), id);
+CREATE INDEX idx_albums_images_image_id ON albums_images(image_id);
+CREATE INDEX idx_albums_images_album_id_position ON albums_images(album_id, position);
\ No newline at end of file
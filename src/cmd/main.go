@@ -19,6 +19,7 @@ import (
 	storageWorker "image-processing-service/src/internal/common/storage/worker"
 	imagesApplication "image-processing-service/src/internal/images/application"
 	"image-processing-service/src/internal/images/application/transformations"
+	imagesDomain "image-processing-service/src/internal/images/domain"
 	imagesInfrastructure "image-processing-service/src/internal/images/infrastructure"
 	imagesInterfaces "image-processing-service/src/internal/images/interfaces"
 	usersApplication "image-processing-service/src/internal/users/application"
@@ -38,6 +39,7 @@ type application struct {
 	dbWorker               *dbWorker.Worker
 	storageWorker          *storageWorker.Worker
 	transformationsService *transformations.Service
+	imagesService          *imagesApplication.ImagesService
 }
 
 func main() {
@@ -60,6 +62,7 @@ func main() {
 	<-ctx.Done()
 	slog.Info("Shutdown step 1: received signal to shutdown")
 
+	app.imagesService.Wait()
 	app.transformationsService.Wait()
 	app.dbWorker.Stop()
 	app.storageWorker.Stop()
@@ -84,6 +87,7 @@ func (a *application) assemble() error {
 	refreshTokenExpiration := os.Getenv("APP_JWT_REFRESH_TOKEN_EXPIRATION")
 	otpExpiration := os.Getenv("APP_OTP_EXPIRATION")
 	cacheExpiration := os.Getenv("APP_CACHE_EXPIRATION")
+	previewVariants := os.Getenv("APP_PREVIEW_VARIANTS")
 
 	postgresUser := os.Getenv("POSTGRES_USER")
 	postgresPassword := os.Getenv("POSTGRES_PASSWORD")
@@ -141,6 +145,14 @@ func (a *application) assemble() error {
 	}
 	cacheExpirationTime := time.Duration(cacheExpirationInt) * time.Minute
 
+	if previewVariants == "" {
+		previewVariants = imagesDomain.DefaultPreviewVariants
+	}
+	previewVariantsParsed, err := imagesDomain.ParsePreviewVariants(previewVariants)
+	if err != nil {
+		return fmt.Errorf("error parsing preview variants: %w", err)
+	}
+
 	redisDBInt, err := strconv.Atoi(redisDB)
 	if err != nil {
 		return fmt.Errorf("error converting redis db to integer: %w", err)
@@ -214,6 +226,7 @@ func (a *application) assemble() error {
 		imagesStorageRepo,
 		imagesCacheRepo,
 		transformationsService,
+		previewVariantsParsed,
 		cacheExpirationTime,
 	)
 	imagesAPI := imagesInterfaces.NewAPI(imagesService)
@@ -228,6 +241,7 @@ func (a *application) assemble() error {
 	a.dbWorker = dbWorker.New(db, txProvider)
 	a.storageWorker = storageWorker.New(db, storageService)
 	a.transformationsService = transformationsService
+	a.imagesService = imagesService
 
 	slog.Info("Init step 17: application assembled")
 
@@ -6,6 +6,7 @@ const (
 	InvalidInput ErrorType = "invalid_input"
 	Unauthorized ErrorType = "unauthorized"
 	Forbidden    ErrorType = "forbidden"
+	Unavailable  ErrorType = "unavailable"
 	Internal     ErrorType = "internal"
 	Unknown      ErrorType = "unknown"
 )
@@ -51,6 +52,13 @@ func NewForbidden(message string) Error {
 	}
 }
 
+func NewUnavailable(message string) Error {
+	return Error{
+		typ: Unavailable,
+		msg: message,
+	}
+}
+
 func NewInternal(message string) Error {
 	return Error{
 		typ: Internal,
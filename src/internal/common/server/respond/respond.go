@@ -24,6 +24,9 @@ func WithError(w http.ResponseWriter, error error) {
 		http.Error(w, commonError.Error(), http.StatusUnauthorized)
 	case commonerrors.Forbidden:
 		http.Error(w, commonError.Error(), http.StatusForbidden)
+	case commonerrors.Unavailable:
+		w.Header().Set("Retry-After", "10")
+		http.Error(w, commonError.Error(), http.StatusServiceUnavailable)
 	case commonerrors.Internal:
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 	case commonerrors.Unknown:
@@ -54,6 +57,19 @@ func WithJSON(w http.ResponseWriter, code int, payload any) {
 	}
 }
 
+// WithBytes sends raw data, such as an image, with the content type detected from the data itself.
+func WithBytes(w http.ResponseWriter, code int, data []byte) {
+	applyCommonHeaders(w)
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.WriteHeader(code)
+
+	_, err := w.Write(data)
+	if err != nil {
+		WithError(w, commonerrors.NewInternal("error sending response"))
+		return
+	}
+}
+
 func applyCommonHeaders(w http.ResponseWriter) {
 	w.Header().Set("X-API-Version", version.Version())
 	w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -76,8 +76,37 @@ func (s *Service) setup() {
 	mux.HandleFunc("PATCH /users/reset-password", s.usersAPI.ResetPassword)
 
 	mux.HandleFunc("POST /images", s.authAPI.UserMiddleware(s.imagesAPI.Upload))
+	mux.HandleFunc("POST /images/composite", s.authAPI.UserMiddleware(s.imagesAPI.Composite))
+	mux.HandleFunc("POST /images/sprite-sheet", s.authAPI.UserMiddleware(s.imagesAPI.CreateSpriteSheet))
+	mux.HandleFunc("POST /images/contact-sheet", s.authAPI.UserMiddleware(s.imagesAPI.CreateContactSheet))
+	mux.HandleFunc("GET /jobs/{id}", s.authAPI.UserMiddleware(s.imagesAPI.GetJob))
 	mux.HandleFunc("GET /images", s.authAPI.UserMiddleware(s.imagesAPI.Get))
 	mux.HandleFunc("GET /images/all", s.authAPI.UserMiddleware(s.imagesAPI.GetAll))
+	mux.HandleFunc("GET /images/duplicates", s.authAPI.UserMiddleware(s.imagesAPI.GetDuplicates))
+	mux.HandleFunc("GET /images/search", s.authAPI.UserMiddleware(s.imagesAPI.Search))
+	mux.HandleFunc("POST /images/search/similar", s.authAPI.UserMiddleware(s.imagesAPI.SearchSimilar))
+	mux.HandleFunc("POST /images/diff", s.authAPI.UserMiddleware(s.imagesAPI.Diff))
+	mux.HandleFunc("GET /images/{name}/variants", s.authAPI.UserMiddleware(s.imagesAPI.GetVariants))
+	mux.HandleFunc("GET /images/{name}/variants/{variant}", s.authAPI.UserMiddleware(s.imagesAPI.GetVariant))
+	mux.HandleFunc("GET /images/{name}/analysis", s.authAPI.UserMiddleware(s.imagesAPI.GetAnalysis))
+	mux.HandleFunc("POST /images/{name}/tags", s.authAPI.UserMiddleware(s.imagesAPI.AddTags))
+	mux.HandleFunc("DELETE /images/{name}/tags", s.authAPI.UserMiddleware(s.imagesAPI.RemoveTags))
+	mux.HandleFunc("POST /images/tags", s.authAPI.UserMiddleware(s.imagesAPI.AddTagsInBulk))
+	mux.HandleFunc("DELETE /images/tags", s.authAPI.UserMiddleware(s.imagesAPI.RemoveTagsInBulk))
+	mux.HandleFunc("GET /tags", s.authAPI.UserMiddleware(s.imagesAPI.GetTags))
+	mux.HandleFunc("POST /albums", s.authAPI.UserMiddleware(s.imagesAPI.CreateAlbum))
+	mux.HandleFunc("GET /albums", s.authAPI.UserMiddleware(s.imagesAPI.GetAlbums))
+	mux.HandleFunc("GET /albums/{name}", s.authAPI.UserMiddleware(s.imagesAPI.GetAlbum))
+	mux.HandleFunc("PATCH /albums/{name}", s.authAPI.UserMiddleware(s.imagesAPI.UpdateAlbum))
+	mux.HandleFunc("DELETE /albums/{name}", s.authAPI.UserMiddleware(s.imagesAPI.DeleteAlbum))
+	mux.HandleFunc("GET /albums/{name}/images", s.authAPI.UserMiddleware(s.imagesAPI.GetAlbumImages))
+	mux.HandleFunc("POST /albums/{name}/images", s.authAPI.UserMiddleware(s.imagesAPI.AddAlbumImages))
+	mux.HandleFunc("DELETE /albums/{name}/images", s.authAPI.UserMiddleware(s.imagesAPI.RemoveAlbumImages))
+	mux.HandleFunc("PUT /albums/{name}/images", s.authAPI.UserMiddleware(s.imagesAPI.ReorderAlbumImages))
+	mux.HandleFunc("POST /folders", s.authAPI.UserMiddleware(s.imagesAPI.CreateFolder))
+	mux.HandleFunc("GET /folders", s.authAPI.UserMiddleware(s.imagesAPI.GetFolders))
+	mux.HandleFunc("PATCH /folders", s.authAPI.UserMiddleware(s.imagesAPI.MoveFolder))
+	mux.HandleFunc("DELETE /folders", s.authAPI.UserMiddleware(s.imagesAPI.DeleteFolder))
 	mux.HandleFunc("PUT /images", s.authAPI.UserMiddleware(s.imagesAPI.UpdateDetails))
 	mux.HandleFunc("PATCH /images", s.authAPI.UserMiddleware(s.imagesAPI.Transform))
 	mux.HandleFunc("DELETE /images", s.authAPI.UserMiddleware(s.imagesAPI.Delete))
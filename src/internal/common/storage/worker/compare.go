@@ -5,13 +5,21 @@ import (
 	"image-processing-service/src/internal/images/domain"
 )
 
+// getAllDanglingImagesNames returns the objects in the storage whose image is no longer in the database.
 func getAllDanglingImagesNames(imagesNamesStorage []string, imagesIDs []uuid.UUID) ([]string, error) {
+	knownIDs := make(map[uuid.UUID]struct{}, len(imagesIDs))
+	for _, imageID := range imagesIDs {
+		knownIDs[imageID] = struct{}{}
+	}
+
 	var danglingImagesIDs []string
 	for _, imageNameStorage := range imagesNamesStorage {
-		for _, imageID := range imagesIDs {
-			if domain.CreateFullImageObjectName(imageID) == imageNameStorage || domain.CreatePreviewImageObjectName(imageID) == imageNameStorage {
-				continue
-			}
+		id, ok := domain.ParseImageObjectName(imageNameStorage)
+		if !ok {
+			continue
+		}
+
+		if _, ok := knownIDs[id]; !ok {
 			danglingImagesIDs = append(danglingImagesIDs, imageNameStorage)
 		}
 	}
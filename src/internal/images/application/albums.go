@@ -0,0 +1,266 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/domain"
+	"slices"
+	"time"
+)
+
+func (s *ImagesService) CreateAlbum(userID uuid.UUID, name, description string) error {
+	err := domain.ValidateAlbumName(name)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid album name: %v", err))
+	}
+
+	err = domain.ValidateDescription(description)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid album description: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = s.imagesDBRepo.CreateAlbum(ctx, userID, name, description)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error creating album in database: %v", err))
+	}
+
+	return nil
+}
+
+func (s *ImagesService) GetAlbum(userID uuid.UUID, name string) (*domain.Album, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	album, err := s.imagesDBRepo.GetAlbumByUserIDAndName(ctx, userID, name)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading album from database: %v", err))
+	}
+
+	return album, nil
+}
+
+func (s *ImagesService) GetAlbums(userID uuid.UUID) ([]*domain.Album, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	albums, err := s.imagesDBRepo.GetAlbumsByUserID(ctx, userID)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading albums from database: %v", err))
+	}
+
+	return albums, nil
+}
+
+// GetAlbumImages reads a page of the images of the album in their order, along with their previews.
+func (s *ImagesService) GetAlbumImages(
+	userID uuid.UUID,
+	name string,
+	page,
+	limit int,
+) ([]*domain.ImageMetadata, [][]byte, int, error) {
+	if page < 1 || limit < 1 || limit > 25 {
+		return nil, nil, -1, commonerrors.NewInvalidInput("invalid page or limit")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	album, err := s.imagesDBRepo.GetAlbumByUserIDAndName(ctx, userID, name)
+	if err != nil {
+		return nil, nil, -1, commonerrors.NewInternal(fmt.Sprintf("error reading album from database: %v", err))
+	}
+
+	imagesMetadata, totalCount, err := s.imagesDBRepo.GetAlbumImagesMetadata(ctx, album.ID, page, limit)
+	if err != nil {
+		return nil, nil, -1, commonerrors.NewInternal(fmt.Sprintf("error reading album images from database: %v", err))
+	}
+
+	imagesBytes, err := s.getPreviews(ctx, imagesMetadata)
+	if err != nil {
+		return nil, nil, -1, err
+	}
+
+	return imagesMetadata, imagesBytes, totalCount, nil
+}
+
+// UpdateAlbum changes the details of the album. The cover has to be one of the images in the album.
+func (s *ImagesService) UpdateAlbum(userID uuid.UUID, oldName, newName, newDescription, newCover string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	album, err := s.imagesDBRepo.GetAlbumByUserIDAndName(ctx, userID, oldName)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error reading album from database: %v", err))
+	}
+
+	newName, newDescription, err = domain.DetermineAlbumToUpdate(album, newName, newDescription, newCover)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid album: %v", err))
+	}
+
+	err = domain.ValidateAlbumName(newName)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid album name: %v", err))
+	}
+
+	err = domain.ValidateDescription(newDescription)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid album description: %v", err))
+	}
+
+	coverImageID := album.CoverImageID
+	if newCover != "" {
+		imagesMetadata, _, err := s.imagesDBRepo.GetAlbumImagesMetadata(ctx, album.ID, 1, domain.MaxAlbumImages)
+		if err != nil {
+			return commonerrors.NewInternal(fmt.Sprintf("error reading album images from database: %v", err))
+		}
+
+		coverPath := domain.NormalizeImagePath(newCover)
+		i := slices.IndexFunc(imagesMetadata, func(imageMetadata *domain.ImageMetadata) bool {
+			return imageMetadata.Path() == coverPath
+		})
+		if i == -1 {
+			return commonerrors.NewInvalidInput(fmt.Sprintf("invalid album cover: image '%s' is not in the album", newCover))
+		}
+		coverImageID = uuid.NullUUID{UUID: imagesMetadata[i].ID, Valid: true}
+	}
+
+	err = s.imagesDBRepo.UpdateAlbumDetails(ctx, album.ID, newName, newDescription, coverImageID)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error updating album in database: %v", err))
+	}
+
+	return nil
+}
+
+// DeleteAlbum deletes the album, leaving the images in it untouched.
+func (s *ImagesService) DeleteAlbum(userID uuid.UUID, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	album, err := s.imagesDBRepo.GetAlbumByUserIDAndName(ctx, userID, name)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error reading album from database: %v", err))
+	}
+
+	err = s.imagesDBRepo.DeleteAlbum(ctx, album.ID)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error deleting album from database: %v", err))
+	}
+
+	return nil
+}
+
+// AddAlbumImages appends the images to the end of the album in the given order. Adding an image that is already in the
+// album changes nothing.
+func (s *ImagesService) AddAlbumImages(userID uuid.UUID, name string, imageNames []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	album, imageIDs, err := s.prepareAlbumImages(ctx, userID, name, imageNames)
+	if err != nil {
+		return err
+	}
+
+	imagesMetadata, _, err := s.imagesDBRepo.GetAlbumImagesMetadata(ctx, album.ID, 1, domain.MaxAlbumImages)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error reading album images from database: %v", err))
+	}
+
+	// Images already in the album do not take up any more room, so only the new ones count towards the limit.
+	imageIDs = domain.NewAlbumImageIDs(imagesMetadata, imageIDs)
+	if len(imageIDs) == 0 {
+		return nil
+	}
+
+	if album.ImageCount+len(imageIDs) > domain.MaxAlbumImages {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("an album cannot contain more than %d images", domain.MaxAlbumImages))
+	}
+
+	err = s.imagesDBRepo.AddAlbumImages(ctx, album.ID, imageIDs)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error adding album images in database: %v", err))
+	}
+
+	return nil
+}
+
+func (s *ImagesService) RemoveAlbumImages(userID uuid.UUID, name string, imageNames []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	album, imageIDs, err := s.prepareAlbumImages(ctx, userID, name, imageNames)
+	if err != nil {
+		return err
+	}
+
+	err = s.imagesDBRepo.RemoveAlbumImages(ctx, album.ID, imageIDs)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error removing album images in database: %v", err))
+	}
+
+	return nil
+}
+
+// ReorderAlbumImages puts the images of the album in the given order, which has to name every image of the album.
+func (s *ImagesService) ReorderAlbumImages(userID uuid.UUID, name string, imageNames []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	album, err := s.imagesDBRepo.GetAlbumByUserIDAndName(ctx, userID, name)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error reading album from database: %v", err))
+	}
+
+	imagesMetadata, _, err := s.imagesDBRepo.GetAlbumImagesMetadata(ctx, album.ID, 1, domain.MaxAlbumImages)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error reading album images from database: %v", err))
+	}
+
+	imageIDs, err := domain.OrderAlbumImages(imagesMetadata, imageNames)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid order: %v", err))
+	}
+
+	err = s.imagesDBRepo.ReorderAlbumImages(ctx, album.ID, imageIDs)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error reordering album images in database: %v", err))
+	}
+
+	return nil
+}
+
+// prepareAlbumImages validates the images of a request changing the images of an album and looks up the album and the
+// IDs of the images.
+func (s *ImagesService) prepareAlbumImages(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+	imageNames []string,
+) (*domain.Album, []uuid.UUID, error) {
+	err := domain.ValidateAlbumImages(imageNames)
+	if err != nil {
+		return nil, nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid images: %v", err))
+	}
+
+	album, err := s.imagesDBRepo.GetAlbumByUserIDAndName(ctx, userID, name)
+	if err != nil {
+		return nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading album from database: %v", err))
+	}
+
+	imageIDs := make([]uuid.UUID, len(imageNames))
+	for i, imageName := range imageNames {
+		imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, imageName)
+		if err != nil {
+			return nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+		}
+		imageIDs[i] = imageMetadata.ID
+	}
+
+	return album, imageIDs, nil
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/domain"
+	"time"
+)
+
+// GetAnalysis returns the analysis of the image if there is one for its current content. Otherwise, it returns the ID of
+// the job analysing the image, starting one unless one is already pending or running. Analyses are tied to the content hash of the image, so a
+// transformation makes the previous analysis stale without having to delete it.
+func (s *ImagesService) GetAnalysis(userID uuid.UUID, name string) (*domain.ImageAnalysis, uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, name)
+	if err != nil {
+		return nil, uuid.Nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+	}
+
+	analysis, err := s.imagesDBRepo.GetImageAnalysis(ctx, imageMetadata.ID, imageMetadata.Hashes.ContentHash)
+	if err != nil {
+		return nil, uuid.Nil, commonerrors.NewInternal(fmt.Sprintf("error reading image analysis from database: %v", err))
+	}
+	if analysis != nil {
+		return analysis, uuid.Nil, nil
+	}
+
+	// Clients poll until the analysis exists, so a job that is already analysing the content is handed out again
+	// instead of queueing the same work once per request.
+	source := domain.JobSource{
+		ImageID:     uuid.NullUUID{UUID: imageMetadata.ID, Valid: true},
+		ContentHash: imageMetadata.Hashes.ContentHash,
+	}
+	job, err := s.imagesDBRepo.GetActiveJobByUserIDAndSource(ctx, userID, domain.JobTypeAnalysis, source)
+	if err != nil {
+		return nil, uuid.Nil, commonerrors.NewInternal(fmt.Sprintf("error reading job from database: %v", err))
+	}
+	if job != nil {
+		return nil, job.ID, nil
+	}
+
+	jobID, err := s.startJob(userID, domain.JobTypeAnalysis, source, func(ctx context.Context) (*domain.ImageMetadata, error) {
+		return s.analyze(ctx, imageMetadata.ID)
+	})
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	return nil, jobID, nil
+}
+
+func (s *ImagesService) analyze(ctx context.Context, id uuid.UUID) (*domain.ImageMetadata, error) {
+	// The image may have changed since the job was started, so the metadata is read again to store the analysis under
+	// the content hash of the image that is actually analysed.
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByID(ctx, id)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+	}
+
+	imageBytes, err := s.getImageBytes(ctx, domain.CreateFullImageObjectName(imageMetadata.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, err := s.transformationsService.Analyze(imageBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.imagesDBRepo.SaveImageAnalysis(ctx, imageMetadata.ID, imageMetadata.Hashes.ContentHash, analysis)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error saving image analysis in database: %v", err))
+	}
+
+	return imageMetadata, nil
+}
@@ -7,6 +7,8 @@ import (
 	commonerrors "image-processing-service/src/internal/common/errors"
 	"image-processing-service/src/internal/images/application/transformations"
 	"image-processing-service/src/internal/images/domain"
+	"log/slog"
+	"slices"
 	"time"
 )
 
@@ -15,6 +17,8 @@ type ImagesService struct {
 	imagesStorageRepo      domain.ImagesStorageRepository
 	imagesCacheRepo        domain.ImagesCacheRepository
 	transformationsService *transformations.Service
+	jobRunner              *jobRunner
+	previewVariants        []domain.PreviewVariant
 	cacheExpiry            time.Duration
 }
 
@@ -23,6 +27,7 @@ func NewService(
 	imagesStorageRepo domain.ImagesStorageRepository,
 	imagesCacheRepo domain.ImagesCacheRepository,
 	transformationsService *transformations.Service,
+	previewVariants []domain.PreviewVariant,
 	cacheExpiry time.Duration,
 ) *ImagesService {
 	return &ImagesService{
@@ -30,66 +35,187 @@ func NewService(
 		imagesStorageRepo:      imagesStorageRepo,
 		imagesCacheRepo:        imagesCacheRepo,
 		transformationsService: transformationsService,
+		jobRunner:              newJobRunner(jobWorkerCount, jobQueueSize),
+		previewVariants:        previewVariants,
 		cacheExpiry:            cacheExpiry,
 	}
 }
 
-func (s *ImagesService) Upload(userID uuid.UUID, name, description string, bytes []byte) error {
-	err := domain.ValidateName(name)
+func (s *ImagesService) Wait() {
+	slog.Info("Shutdown step 2: waiting for all image jobs to finish")
+	s.jobRunner.wait()
+}
+
+// Upload stores the image and returns the images of the user it duplicates, either exactly or nearly, so that the user
+// can be warned about them. The image is stored either way.
+func (s *ImagesService) Upload(userID uuid.UUID, name, description string, bytes []byte) ([]*domain.SimilarImage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	imageMetadata, err := s.upload(ctx, userID, name, description, bytes)
 	if err != nil {
-		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid image name: %v", err))
+		return nil, err
+	}
+
+	// The hashes are only known after the upload, so the metadata is read again to get them.
+	imageMetadata, err = s.imagesDBRepo.GetImageMetadataByID(ctx, imageMetadata.ID)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+	}
+
+	hashes := imageMetadata.Hashes
+	if hashes.ContentHash == "" {
+		return nil, nil
+	}
+
+	duplicates, err := s.imagesDBRepo.GetImagesMetadataByUserIDAndContentHash(ctx, userID, hashes.ContentHash)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images metadata from database: %v", err))
+	}
+
+	var similarImages []*domain.SimilarImage
+	for _, duplicate := range duplicates {
+		if duplicate.ID != imageMetadata.ID {
+			similarImages = append(similarImages, &domain.SimilarImage{Image: duplicate, Exact: true})
+		}
+	}
+
+	// The exact duplicates have been found already, so only the near duplicates are left.
+	candidates, err := s.imagesDBRepo.GetImagesHashesByUserIDAndPHash(ctx, userID, hashes.PHash, domain.DefaultSimilarityDistance)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images hashes from database: %v", err))
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(candidate *domain.HashedImage) bool {
+		return candidate.Hashes.ContentHash == hashes.ContentHash
+	})
+
+	matches := domain.FindHashMatches(hashes, candidates, domain.DefaultSimilarityDistance)
+	if len(matches) == 0 {
+		return similarImages, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+
+	imagesMetadata, err := s.imagesDBRepo.GetImagesMetadataByUserIDAndIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images metadata from database: %v", err))
+	}
+
+	return append(similarImages, domain.NewSimilarImages(matches, imagesMetadata)...), nil
+}
+
+// upload stores a new image together with its preview and returns its metadata, so that images created by the service
+// itself can be referenced afterwards. The image is stored at the path, whose folder has to exist.
+func (s *ImagesService) upload(
+	ctx context.Context,
+	userID uuid.UUID,
+	path,
+	description string,
+	bytes []byte,
+) (*domain.ImageMetadata, error) {
+	folder, name, err := validateImagePath(path)
+	if err != nil {
+		return nil, err
 	}
 
 	err = domain.ValidateDescription(description)
 	if err != nil {
-		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid image description: %v", err))
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid image description: %v", err))
 	}
 
 	err = domain.ValidateImage(bytes)
 	if err != nil {
-		return commonerrors.NewInvalidInput("invalid image data")
+		return nil, commonerrors.NewInvalidInput("invalid image data")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	if folder != domain.RootFolder {
+		existingFolder, err := s.getFolder(ctx, userID, folder)
+		if err != nil {
+			return nil, err
+		}
+		if existingFolder == nil {
+			return nil, commonerrors.NewInvalidInput(fmt.Sprintf("folder %s does not exist", folder))
+		}
+	}
 
-	imageMetadata, err := s.imagesDBRepo.CreateImageMetadata(ctx, userID, name, description)
+	imageMetadata, err := s.imagesDBRepo.CreateImageMetadata(ctx, userID, folder, name, description)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error creating image in database: %v", err))
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error creating image in database: %v", err))
 	}
 
-	fullImageObjectName := domain.CreateFullImageObjectName(imageMetadata.ID)
-	err = s.imagesStorageRepo.UploadImage(ctx, fullImageObjectName, bytes)
+	err = s.storeImage(ctx, imageMetadata.ID, bytes)
+	if err != nil {
+		// Otherwise the name would stay taken by an image without content, and a retried upload would fail.
+		s.discardImage(imageMetadata.ID)
+		return nil, err
+	}
+
+	return imageMetadata, nil
+}
+
+// storeImage stores the content of a newly created image together with everything derived from it.
+func (s *ImagesService) storeImage(ctx context.Context, id uuid.UUID, imageBytes []byte) error {
+	fullImageObjectName := domain.CreateFullImageObjectName(id)
+	err := s.imagesStorageRepo.UploadImage(ctx, fullImageObjectName, imageBytes)
 	if err != nil {
 		return commonerrors.NewInternal(fmt.Sprintf("error uploading image to storage: %v", err))
 	}
-	err = s.imagesCacheRepo.CacheImage(ctx, fullImageObjectName, bytes, s.cacheExpiry)
+	err = s.imagesCacheRepo.CacheImage(ctx, fullImageObjectName, imageBytes, s.cacheExpiry)
 	if err != nil {
 		return commonerrors.NewInternal(fmt.Sprintf("error caching image: %v", err))
 	}
 
-	previewBytes, err := s.transformationsService.CreatePreview(bytes)
-	previewImageObjectName := domain.CreatePreviewImageObjectName(imageMetadata.ID)
+	return s.storeDerivatives(ctx, id, imageBytes)
+}
+
+// discardImage deletes an image whose upload failed halfway. The errors are only logged, since the upload already
+// failed, and any derived objects left in the storage are deleted by the storage worker once the metadata is gone.
+func (s *ImagesService) discardImage(id uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := s.imagesDBRepo.DeleteImageMetadata(ctx, id)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error creating preview image: %v", err))
+		slog.Error("Error discarding image", "id", id, "error", err)
 	}
-	err = s.imagesStorageRepo.UploadImage(ctx, previewImageObjectName, previewBytes)
+
+	fullImageObjectName := domain.CreateFullImageObjectName(id)
+	err = s.imagesStorageRepo.DeleteImage(ctx, fullImageObjectName)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error uploading preview image to storage: %v", err))
+		slog.Error("Error discarding image", "id", id, "error", err)
 	}
-	err = s.imagesCacheRepo.CacheImage(ctx, previewImageObjectName, previewBytes, s.cacheExpiry)
+	err = s.imagesCacheRepo.DeleteImage(ctx, fullImageObjectName)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error caching preview image: %v", err))
+		slog.Error("Error discarding image", "id", id, "error", err)
+	}
+}
+
+// validateImagePath splits the path of a new image into its folder and name and validates both. Images created by the
+// service are validated the same way up front, so that they are not rendered only to be rejected by upload.
+func validateImagePath(path string) (string, string, error) {
+	folder, name := domain.SplitImagePath(path)
+	err := domain.ValidateFolderPath(folder)
+	if err != nil {
+		return "", "", commonerrors.NewInvalidInput(fmt.Sprintf("invalid image folder: %v", err))
 	}
 
-	return nil
+	err = domain.ValidateName(name)
+	if err != nil {
+		return "", "", commonerrors.NewInvalidInput(fmt.Sprintf("invalid image name: %v", err))
+	}
+
+	return folder, name, nil
 }
 
 func (s *ImagesService) Get(userID uuid.UUID, name string) (*domain.ImageMetadata, []byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndName(ctx, userID, name)
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, name)
 	if err != nil {
 		return nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
 	}
@@ -113,47 +239,85 @@ func (s *ImagesService) Get(userID uuid.UUID, name string) (*domain.ImageMetadat
 	return imageMetadata, imageBytes, nil
 }
 
-func (s *ImagesService) GetAll(userID uuid.UUID, page, limit int) ([]*domain.ImageMetadata, [][]byte, int, error) {
+// GetAll reads a page of the images of the user along with their previews and the cursors of the next and previous
+// pages, which are nil if there are no images in that direction.
+func (s *ImagesService) GetAll(
+	userID uuid.UUID,
+	filter domain.ImagesFilter,
+	listing domain.ImagesListing,
+) ([]*domain.ImageMetadata, [][]byte, int, *domain.Cursor, *domain.Cursor, error) {
+	err := domain.ValidateImagesListing(listing)
+	if err != nil {
+		return nil, nil, -1, nil, nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid listing: %v", err))
+	}
+
+	filter.Tags = domain.NormalizeTags(filter.Tags)
+	err = domain.ValidateImagesFilter(filter)
+	if err != nil {
+		return nil, nil, -1, nil, nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid filter: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	imagesMetadata, totalCount, err := s.imagesDBRepo.ListImagesMetadataByUserID(ctx, userID, filter, listing)
+	if err != nil {
+		return nil, nil, -1, nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading images metadata from database: %v", err))
+	}
+
+	imagesMetadata, next, prev := domain.PaginateImages(imagesMetadata, listing)
+
+	imagesBytes, err := s.getPreviews(ctx, imagesMetadata)
+	if err != nil {
+		return nil, nil, -1, nil, nil, err
+	}
+
+	return imagesMetadata, imagesBytes, totalCount, next, prev, nil
+}
+
+// Search finds the images of the user whose name, description or tags contain the words of the query, the best matches
+// first.
+func (s *ImagesService) Search(
+	userID uuid.UUID,
+	query string,
+	page,
+	limit int,
+) ([]*domain.SearchResult, [][]byte, int, error) {
 	if page < 1 || limit < 1 || limit > 25 {
 		return nil, nil, -1, commonerrors.NewInvalidInput("invalid page or limit")
 	}
 
+	textQuery, err := domain.ParseSearchQuery(query)
+	if err != nil {
+		return nil, nil, -1, commonerrors.NewInvalidInput(fmt.Sprintf("invalid query: %v", err))
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	imagesMetadata, totalCount, err := s.imagesDBRepo.GetImagesMetadataByUserID(ctx, userID, page, limit)
+	results, totalCount, err := s.imagesDBRepo.SearchImagesMetadataByUserID(ctx, userID, textQuery, page, limit)
 	if err != nil {
-		return nil, nil, -1, commonerrors.NewInternal(fmt.Sprintf("error reading images metadata from database: %v", err))
+		return nil, nil, -1, commonerrors.NewInternal(fmt.Sprintf("error searching images metadata in database: %v", err))
 	}
 
-	imagesBytes := make([][]byte, len(imagesMetadata))
-	for i, imageMetadata := range imagesMetadata {
-		previewImageObjectName := domain.CreatePreviewImageObjectName(imageMetadata.ID)
-		imageBytes, err := s.imagesCacheRepo.GetImage(ctx, previewImageObjectName)
-		if err != nil {
-			return nil, nil, -1, commonerrors.NewInternal(fmt.Sprintf("error reading image from cache: %v", err))
-		}
-		if imageBytes == nil {
-			imageBytes, err = s.imagesStorageRepo.DownloadImage(ctx, previewImageObjectName)
-			if err != nil {
-				return nil, nil, -1, commonerrors.NewInternal(fmt.Sprintf("error downloading image from storage: %v", err))
-			}
-			err = s.imagesCacheRepo.CacheImage(ctx, previewImageObjectName, imageBytes, s.cacheExpiry)
-			if err != nil {
-				return nil, nil, -1, commonerrors.NewInternal(fmt.Sprintf("error caching image: %v", err))
-			}
-		}
-		imagesBytes[i] = imageBytes
+	imagesMetadata := make([]*domain.ImageMetadata, len(results))
+	for i, result := range results {
+		imagesMetadata[i] = result.Image
+	}
+
+	imagesBytes, err := s.getPreviews(ctx, imagesMetadata)
+	if err != nil {
+		return nil, nil, -1, err
 	}
 
-	return imagesMetadata, imagesBytes, totalCount, nil
+	return results, imagesBytes, totalCount, nil
 }
 
 func (s *ImagesService) UpdateDetails(userID uuid.UUID, oldName, newName, newDescription string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndName(ctx, userID, oldName)
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, oldName)
 	if err != nil {
 		return commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
 	}
@@ -189,7 +353,7 @@ func (s *ImagesService) Transform(
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndName(ctx, userID, name)
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, name)
 	if err != nil {
 		return commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
 	}
@@ -210,9 +374,11 @@ func (s *ImagesService) Transform(
 		}
 	}
 
+	// Errors returned by the transformations service are already typed, so that invalid transformation options are
+	// reported back to the user instead of being masked as internal errors.
 	transformedBytes, err := s.transformationsService.Apply(imageBytes, transformations)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error applying transformations: %v", err))
+		return err
 	}
 
 	err = s.imagesStorageRepo.UploadImage(ctx, fullImageObjectName, transformedBytes)
@@ -224,33 +390,304 @@ func (s *ImagesService) Transform(
 		return commonerrors.NewInternal(fmt.Sprintf("error caching image: %v", err))
 	}
 
-	previewBytes, err := s.transformationsService.CreatePreview(transformedBytes)
-	previewImageObjectName := domain.CreatePreviewImageObjectName(imageMetadata.ID)
+	err = s.storeDerivatives(ctx, imageMetadata.ID, transformedBytes)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error creating preview image: %v", err))
+		return err
 	}
-	err = s.imagesStorageRepo.UploadImage(ctx, previewImageObjectName, previewBytes)
+
+	err = s.imagesDBRepo.UpdateImageMetadataUpdatedAt(ctx, imageMetadata.ID)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error uploading preview image to storage: %v", err))
+		return commonerrors.NewInternal(fmt.Sprintf("error updating image metadata in database: %v", err))
 	}
-	err = s.imagesCacheRepo.CacheImage(ctx, previewImageObjectName, previewBytes, s.cacheExpiry)
+
+	return nil
+}
+
+func (s *ImagesService) Composite(
+	userID uuid.UUID,
+	name,
+	description string,
+	composition domain.Composition,
+) error {
+	_, _, err := validateImagePath(name)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error caching preview image: %v", err))
+		return err
 	}
 
-	err = s.imagesDBRepo.UpdateImageMetadataUpdatedAt(ctx, imageMetadata.ID)
+	err = domain.ValidateDescription(description)
 	if err != nil {
-		return commonerrors.NewInternal(fmt.Sprintf("error updating image metadata in database: %v", err))
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid image description: %v", err))
 	}
 
-	return nil
+	err = domain.ValidateComposition(composition)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid composition: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	imagesBytes := make([][]byte, len(composition.Layers))
+	for i, layer := range composition.Layers {
+		imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, layer.Name)
+		if err != nil {
+			return commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+		}
+
+		imagesBytes[i], err = s.getImageBytes(ctx, domain.CreateFullImageObjectName(imageMetadata.ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	compositeBytes, err := s.transformationsService.Compose(imagesBytes, composition)
+	if err != nil {
+		return err
+	}
+
+	// The composite is stored like any uploaded image, so it gets its own metadata and preview.
+	_, err = s.upload(ctx, userID, name, description, compositeBytes)
+	return err
+}
+
+func (s *ImagesService) GetVariants(userID uuid.UUID, name string) ([]*domain.ImageVariant, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, name)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+	}
+
+	variants := make([]*domain.ImageVariant, len(s.previewVariants))
+	for i, variant := range s.previewVariants {
+		variantBytes, err := s.getImageBytes(ctx, domain.CreateVariantImageObjectName(imageMetadata.ID, variant.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		width, height, err := s.transformationsService.Dimensions(variantBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		variants[i] = &domain.ImageVariant{Name: variant.Name, Width: width, Height: height}
+	}
+
+	return variants, nil
+}
+
+func (s *ImagesService) GetVariant(userID uuid.UUID, name, variantName string) ([]byte, error) {
+	variant, ok := domain.FindPreviewVariant(s.previewVariants, variantName)
+	if !ok {
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("variant '%s' does not exist", variantName))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, name)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+	}
+
+	return s.getImageBytes(ctx, domain.CreateVariantImageObjectName(imageMetadata.ID, variant.Name))
+}
+
+func (s *ImagesService) GetDuplicates(userID uuid.UUID, maxDistance int) ([][]*domain.ImageMetadata, error) {
+	err := domain.ValidateSimilarityDistance(maxDistance)
+	if err != nil {
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid distance: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	images, err := s.imagesDBRepo.GetImagesHashesByUserID(ctx, userID)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images hashes from database: %v", err))
+	}
+
+	groups := domain.GroupSimilarImages(images, maxDistance)
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	var ids []uuid.UUID
+	for _, group := range groups {
+		ids = append(ids, group...)
+	}
+
+	imagesMetadata, err := s.imagesDBRepo.GetImagesMetadataByUserIDAndIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images metadata from database: %v", err))
+	}
+
+	return domain.NewImageGroups(groups, imagesMetadata), nil
+}
+
+// SearchSimilar ranks the images of the user by how similar they look to either one of their images, given by its name,
+// or to an uploaded image, which is not stored.
+func (s *ImagesService) SearchSimilar(
+	userID uuid.UUID,
+	name string,
+	imageBytes []byte,
+	maxDistance,
+	limit int,
+) ([]*domain.SimilarImage, error) {
+	err := domain.ValidateSimilarityDistance(maxDistance)
+	if err != nil {
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid distance: %v", err))
+	}
+
+	err = domain.ValidateSimilarImagesLimit(limit)
+	if err != nil {
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid limit: %v", err))
+	}
+
+	if (name == "") == (imageBytes == nil) {
+		return nil, commonerrors.NewInvalidInput("either an image name or an image file is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var query domain.ImageHashes
+	queryID := uuid.Nil
+	if name != "" {
+		imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, name)
+		if err != nil {
+			return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+		}
+		query, queryID = imageMetadata.Hashes, imageMetadata.ID
+
+		// Images stored before the hashes were introduced have none yet, so they are computed on the fly.
+		if query.ContentHash == "" {
+			imageBytes, err = s.getImageBytes(ctx, domain.CreateFullImageObjectName(imageMetadata.ID))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if imageBytes != nil {
+		err = domain.ValidateImage(imageBytes)
+		if err != nil {
+			return nil, commonerrors.NewInvalidInput("invalid image data")
+		}
+
+		previewBytes, err := s.transformationsService.CreatePreview(imageBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		query, err = s.transformationsService.CreateHashes(imageBytes, previewBytes)
+		if err != nil {
+			return nil, commonerrors.NewInternal(fmt.Sprintf("error creating hashes: %v", err))
+		}
+	}
+
+	candidates, err := s.imagesDBRepo.GetImagesHashesByUserIDAndPHash(ctx, userID, query.PHash, maxDistance)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images hashes from database: %v", err))
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(candidate *domain.HashedImage) bool {
+		return candidate.ID == queryID
+	})
+
+	matches := domain.FindHashMatches(query, candidates, maxDistance)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	matches = matches[:min(limit, len(matches))]
+
+	ids := make([]uuid.UUID, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+
+	imagesMetadata, err := s.imagesDBRepo.GetImagesMetadataByUserIDAndIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images metadata from database: %v", err))
+	}
+
+	return domain.NewSimilarImages(matches, imagesMetadata), nil
+}
+
+// Diff compares two images, each of which is either one of the images of the user, one of their preview variants or an
+// uploaded image, and returns a diff image together with the metrics of the comparison.
+func (s *ImagesService) Diff(
+	userID uuid.UUID,
+	first,
+	second domain.DiffSource,
+	resize bool,
+	threshold int,
+) ([]byte, *domain.DiffMetrics, error) {
+	err := domain.ValidateDiffThreshold(threshold)
+	if err != nil {
+		return nil, nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid threshold: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	firstBytes, err := s.getDiffSourceBytes(ctx, userID, first)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secondBytes, err := s.getDiffSourceBytes(ctx, userID, second)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.transformationsService.Diff(firstBytes, secondBytes, resize, threshold)
+}
+
+func (s *ImagesService) getDiffSourceBytes(ctx context.Context, userID uuid.UUID, source domain.DiffSource) ([]byte, error) {
+	if (source.Name == "") == (source.Bytes == nil) {
+		return nil, commonerrors.NewInvalidInput("either an image name or an image file is required for both images")
+	}
+
+	if source.Bytes != nil {
+		err := domain.ValidateImage(source.Bytes)
+		if err != nil {
+			return nil, commonerrors.NewInvalidInput("invalid image data")
+		}
+
+		if source.Variant != "" {
+			return nil, commonerrors.NewInvalidInput("a variant can only be compared for a stored image")
+		}
+
+		return source.Bytes, nil
+	}
+
+	objectName := domain.CreateFullImageObjectName
+	if source.Variant != "" {
+		variant, ok := domain.FindPreviewVariant(s.previewVariants, source.Variant)
+		if !ok {
+			return nil, commonerrors.NewInvalidInput(fmt.Sprintf("variant '%s' does not exist", source.Variant))
+		}
+
+		objectName = func(id uuid.UUID) string {
+			return domain.CreateVariantImageObjectName(id, variant.Name)
+		}
+	}
+
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, source.Name)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+	}
+
+	return s.getImageBytes(ctx, objectName(imageMetadata.ID))
 }
 
 func (s *ImagesService) Delete(userID uuid.UUID, name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndName(ctx, userID, name)
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, name)
 	if err != nil {
 		return commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
 	}
@@ -282,6 +719,15 @@ func (s *ImagesService) Delete(userID uuid.UUID, name string) error {
 		return commonerrors.NewInternal(fmt.Sprintf("error deleting preview image from cache: %v", err))
 	}
 
+	// Images uploaded before a variant was configured do not have it, so the variants are only dropped from the cache
+	// here and left in the storage for the storage worker to delete.
+	for _, variant := range s.previewVariants {
+		err = s.imagesCacheRepo.DeleteImage(ctx, domain.CreateVariantImageObjectName(imageMetadata.ID, variant.Name))
+		if err != nil {
+			return commonerrors.NewInternal(fmt.Sprintf("error deleting variant image from cache: %v", err))
+		}
+	}
+
 	return nil
 }
 
@@ -312,3 +758,116 @@ func (s *ImagesService) AdminDeleteImage(id uuid.UUID) error {
 
 	return nil
 }
+
+// storeDerivatives creates everything that is derived from the content of an image, i.e. the preview, the placeholders,
+// the hashes, the palette, the properties and all preview variants, and stores it, replacing any previous versions.
+func (s *ImagesService) storeDerivatives(ctx context.Context, id uuid.UUID, imageBytes []byte) error {
+	previewBytes, err := s.transformationsService.CreatePreview(imageBytes)
+	previewImageObjectName := domain.CreatePreviewImageObjectName(id)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error creating preview image: %v", err))
+	}
+	err = s.imagesStorageRepo.UploadImage(ctx, previewImageObjectName, previewBytes)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error uploading preview image to storage: %v", err))
+	}
+	err = s.imagesCacheRepo.CacheImage(ctx, previewImageObjectName, previewBytes, s.cacheExpiry)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error caching preview image: %v", err))
+	}
+
+	blurHash, lqip, err := s.transformationsService.CreatePlaceholders(previewBytes)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error creating placeholders: %v", err))
+	}
+	err = s.imagesDBRepo.UpdateImageMetadataPlaceholders(ctx, id, blurHash, lqip)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error updating image metadata in database: %v", err))
+	}
+
+	hashes, err := s.transformationsService.CreateHashes(imageBytes, previewBytes)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error creating hashes: %v", err))
+	}
+	err = s.imagesDBRepo.UpdateImageMetadataHashes(ctx, id, hashes)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error updating image metadata in database: %v", err))
+	}
+
+	palette, err := s.transformationsService.CreatePalette(previewBytes)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error creating palette: %v", err))
+	}
+	err = s.imagesDBRepo.UpdateImageMetadataPalette(ctx, id, palette)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error updating image metadata in database: %v", err))
+	}
+
+	properties, err := s.transformationsService.Properties(imageBytes)
+	if err != nil {
+		return err
+	}
+	err = s.imagesDBRepo.UpdateImageMetadataProperties(ctx, id, properties)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error updating image metadata in database: %v", err))
+	}
+
+	sizes := make([]int, len(s.previewVariants))
+	for i, variant := range s.previewVariants {
+		sizes[i] = variant.Size
+	}
+	variantsBytes, err := s.transformationsService.CreateVariants(imageBytes, sizes)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error creating variant images: %v", err))
+	}
+
+	for i, variant := range s.previewVariants {
+		variantBytes := variantsBytes[i]
+		variantImageObjectName := domain.CreateVariantImageObjectName(id, variant.Name)
+		err = s.imagesStorageRepo.UploadImage(ctx, variantImageObjectName, variantBytes)
+		if err != nil {
+			return commonerrors.NewInternal(fmt.Sprintf("error uploading variant image to storage: %v", err))
+		}
+		err = s.imagesCacheRepo.CacheImage(ctx, variantImageObjectName, variantBytes, s.cacheExpiry)
+		if err != nil {
+			return commonerrors.NewInternal(fmt.Sprintf("error caching variant image: %v", err))
+		}
+	}
+
+	return nil
+}
+
+func (s *ImagesService) getPreviews(ctx context.Context, imagesMetadata []*domain.ImageMetadata) ([][]byte, error) {
+	imagesBytes := make([][]byte, len(imagesMetadata))
+	for i, imageMetadata := range imagesMetadata {
+		imageBytes, err := s.getImageBytes(ctx, domain.CreatePreviewImageObjectName(imageMetadata.ID))
+		if err != nil {
+			return nil, err
+		}
+		imagesBytes[i] = imageBytes
+	}
+
+	return imagesBytes, nil
+}
+
+// getImageBytes reads an image object from the cache, falling back to the storage and caching the result on a miss.
+func (s *ImagesService) getImageBytes(ctx context.Context, objectName string) ([]byte, error) {
+	imageBytes, err := s.imagesCacheRepo.GetImage(ctx, objectName)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image from cache: %v", err))
+	}
+	if imageBytes != nil {
+		return imageBytes, nil
+	}
+
+	imageBytes, err = s.imagesStorageRepo.DownloadImage(ctx, objectName)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error downloading image from storage: %v", err))
+	}
+	err = s.imagesCacheRepo.CacheImage(ctx, objectName, imageBytes, s.cacheExpiry)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error caching image: %v", err))
+	}
+
+	return imageBytes, nil
+}
@@ -0,0 +1,466 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"image"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/application/transformations"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"image/png"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeImagesDBRepository keeps images and folders in memory. Only the methods used by the tests are implemented, the
+// others panic through the embedded nil interface.
+type fakeImagesDBRepository struct {
+	domain.ImagesDBRepository
+	mu       sync.Mutex
+	images   map[uuid.UUID]*domain.ImageMetadata
+	folders  map[string]*domain.Folder
+	jobs     map[uuid.UUID]*domain.Job
+	analyses map[uuid.UUID]*domain.ImageAnalysis
+}
+
+func newFakeImagesDBRepository() *fakeImagesDBRepository {
+	return &fakeImagesDBRepository{
+		images:   make(map[uuid.UUID]*domain.ImageMetadata),
+		folders:  make(map[string]*domain.Folder),
+		jobs:     make(map[uuid.UUID]*domain.Job),
+		analyses: make(map[uuid.UUID]*domain.ImageAnalysis),
+	}
+}
+
+func (r *fakeImagesDBRepository) CreateImageMetadata(
+	_ context.Context,
+	userID uuid.UUID,
+	folder,
+	name,
+	description string,
+) (*domain.ImageMetadata, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, imageMetadata := range r.images {
+		if imageMetadata.UserID == userID && imageMetadata.Folder == folder && imageMetadata.Name == name {
+			return nil, fmt.Errorf("duplicate key value violates unique constraint \"unique_name_per_folder\"")
+		}
+	}
+
+	imageMetadata := domain.NewImageMetadata(userID, folder, name, description)
+	r.images[imageMetadata.ID] = imageMetadata
+	return imageMetadata, nil
+}
+
+func (r *fakeImagesDBRepository) GetImageMetadataByUserIDAndPath(
+	_ context.Context,
+	userID uuid.UUID,
+	path string,
+) (*domain.ImageMetadata, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path = domain.NormalizeImagePath(path)
+	for _, imageMetadata := range r.images {
+		if imageMetadata.UserID == userID && imageMetadata.Path() == path {
+			return imageMetadata, nil
+		}
+	}
+
+	return nil, fmt.Errorf("image %s not found", path)
+}
+
+func (r *fakeImagesDBRepository) GetImageMetadataByID(_ context.Context, id uuid.UUID) (*domain.ImageMetadata, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	imageMetadata, ok := r.images[id]
+	if !ok {
+		return nil, fmt.Errorf("image %s not found", id)
+	}
+
+	return imageMetadata, nil
+}
+
+func (r *fakeImagesDBRepository) GetFolderByUserIDAndPath(
+	_ context.Context,
+	userID uuid.UUID,
+	path string,
+) (*domain.Folder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	folder, ok := r.folders[path]
+	if !ok || folder.UserID != userID {
+		return nil, nil
+	}
+
+	return folder, nil
+}
+
+func (r *fakeImagesDBRepository) update(id uuid.UUID, update func(imageMetadata *domain.ImageMetadata)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	imageMetadata, ok := r.images[id]
+	if !ok {
+		return fmt.Errorf("image %s not found", id)
+	}
+
+	update(imageMetadata)
+	return nil
+}
+
+func (r *fakeImagesDBRepository) UpdateImageMetadataPlaceholders(_ context.Context, id uuid.UUID, blurHash, lqip string) error {
+	return r.update(id, func(imageMetadata *domain.ImageMetadata) {
+		imageMetadata.BlurHash, imageMetadata.LQIP = blurHash, lqip
+	})
+}
+
+func (r *fakeImagesDBRepository) UpdateImageMetadataHashes(_ context.Context, id uuid.UUID, hashes domain.ImageHashes) error {
+	return r.update(id, func(imageMetadata *domain.ImageMetadata) {
+		imageMetadata.Hashes = hashes
+	})
+}
+
+func (r *fakeImagesDBRepository) UpdateImageMetadataPalette(_ context.Context, id uuid.UUID, palette domain.Palette) error {
+	return r.update(id, func(imageMetadata *domain.ImageMetadata) {
+		imageMetadata.Palette = palette
+	})
+}
+
+func (r *fakeImagesDBRepository) UpdateImageMetadataProperties(
+	_ context.Context,
+	id uuid.UUID,
+	properties domain.ImageProperties,
+) error {
+	return r.update(id, func(imageMetadata *domain.ImageMetadata) {
+		imageMetadata.Properties = properties
+	})
+}
+
+func (r *fakeImagesDBRepository) DeleteImageMetadata(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.images, id)
+	return nil
+}
+
+func (r *fakeImagesDBRepository) CreateJob(
+	_ context.Context,
+	userID uuid.UUID,
+	jobType domain.JobType,
+	source domain.JobSource,
+) (*domain.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job := domain.NewJob(userID, jobType, source)
+	r.jobs[job.ID] = job
+	return job, nil
+}
+
+func (r *fakeImagesDBRepository) GetActiveJobByUserIDAndSource(
+	_ context.Context,
+	userID uuid.UUID,
+	jobType domain.JobType,
+	source domain.JobSource,
+) (*domain.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, job := range r.jobs {
+		active := job.Status == domain.JobStatusPending || job.Status == domain.JobStatusRunning
+		if job.UserID == userID && job.Type == jobType && job.Source == source && active {
+			return job, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeImagesDBRepository) UpdateJob(
+	_ context.Context,
+	id uuid.UUID,
+	status domain.JobStatus,
+	imageID uuid.NullUUID,
+	errorMessage string,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return fmt.Errorf("job %s not found", id)
+	}
+
+	job.Status, job.ImageID, job.Error = status, imageID, errorMessage
+	return nil
+}
+
+func (r *fakeImagesDBRepository) DeleteJob(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.jobs, id)
+	return nil
+}
+
+func (r *fakeImagesDBRepository) GetImageAnalysis(
+	_ context.Context,
+	imageID uuid.UUID,
+	_ string,
+) (*domain.ImageAnalysis, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.analyses[imageID], nil
+}
+
+func (r *fakeImagesDBRepository) SaveImageAnalysis(
+	_ context.Context,
+	imageID uuid.UUID,
+	_ string,
+	analysis *domain.ImageAnalysis,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.analyses[imageID] = analysis
+	return nil
+}
+
+// fakeObjectRepository stands in for both the storage and the cache, keeping the objects in memory. Uploads fail while
+// failUploads is set.
+type fakeObjectRepository struct {
+	mu          sync.Mutex
+	objects     map[string][]byte
+	failUploads bool
+}
+
+func newFakeObjectRepository() *fakeObjectRepository {
+	return &fakeObjectRepository{objects: make(map[string][]byte)}
+}
+
+func (r *fakeObjectRepository) UploadImage(_ context.Context, name string, bytes []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failUploads {
+		return fmt.Errorf("upload of %s failed", name)
+	}
+
+	r.objects[name] = bytes
+	return nil
+}
+
+func (r *fakeObjectRepository) DownloadImage(_ context.Context, name string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bytes, ok := r.objects[name]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", name)
+	}
+
+	return bytes, nil
+}
+
+func (r *fakeObjectRepository) CacheImage(_ context.Context, key string, bytes []byte, _ time.Duration) error {
+	return r.UploadImage(context.Background(), key, bytes)
+}
+
+func (r *fakeObjectRepository) GetImage(_ context.Context, key string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.objects[key], nil
+}
+
+func (r *fakeObjectRepository) DeleteImage(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.objects, name)
+	return nil
+}
+
+func newTestService() (*ImagesService, *fakeImagesDBRepository, *fakeObjectRepository) {
+	dbRepo := newFakeImagesDBRepository()
+	storageRepo := newFakeObjectRepository()
+	service := NewService(
+		dbRepo,
+		storageRepo,
+		newFakeObjectRepository(),
+		transformations.NewService(),
+		[]domain.PreviewVariant{{Name: "small", Size: 16}},
+		time.Minute,
+	)
+
+	return service, dbRepo, storageRepo
+}
+
+func generateTestImage(t *testing.T, c color.Color) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 32, 32))
+	for y := range 32 {
+		for x := range 32 {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestImagesService_upload(t *testing.T) {
+	userID := uuid.New()
+	service, dbRepo, storageRepo := newTestService()
+	imageBytes := generateTestImage(t, color.NRGBA{R: 255, A: 255})
+
+	storageRepo.failUploads = true
+	if _, err := service.upload(context.Background(), userID, "red.png", "", imageBytes); err == nil {
+		t.Fatalf("upload() error = nil, want the storage error")
+	}
+	if len(dbRepo.images) != 0 {
+		t.Fatalf("upload() left %d images behind, want none", len(dbRepo.images))
+	}
+
+	storageRepo.failUploads = false
+	imageMetadata, err := service.upload(context.Background(), userID, "red.png", "", imageBytes)
+	if err != nil {
+		t.Fatalf("upload() error = %v, want the retry to succeed", err)
+	}
+
+	variantBytes, err := storageRepo.DownloadImage(context.Background(), domain.CreateVariantImageObjectName(imageMetadata.ID, "small"))
+	if err != nil {
+		t.Fatalf("upload() did not store the variant: %v", err)
+	}
+	variant, _, err := image.Decode(bytes.NewReader(variantBytes))
+	if err != nil {
+		t.Fatalf("failed to decode variant: %v", err)
+	}
+	if variant.Bounds().Dx() != 16 || variant.Bounds().Dy() != 16 {
+		t.Errorf("upload() variant size = %v, want 16x16", variant.Bounds().Size())
+	}
+}
+
+func TestImagesService_Composite(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"Root folder", "composite.png", false},
+		{"Folder", "/renders/composite.png", false},
+		{"Missing folder", "/missing/composite.png", true},
+		{"Invalid name", "/renders/a", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, dbRepo, _ := newTestService()
+			dbRepo.folders["/renders"] = &domain.Folder{ID: uuid.New(), UserID: userID, Path: "/renders"}
+
+			for _, layer := range []struct {
+				name  string
+				color color.Color
+			}{{"red.png", color.NRGBA{R: 255, A: 255}}, {"blue.png", color.NRGBA{B: 255, A: 255}}} {
+				_, err := service.upload(context.Background(), userID, layer.name, "", generateTestImage(t, layer.color))
+				if err != nil {
+					t.Fatalf("failed to upload test image: %v", err)
+				}
+			}
+
+			err := service.Composite(userID, tt.path, "", domain.Composition{
+				Layout: domain.CompositionLayoutHorizontal,
+				Layers: []domain.CompositionLayer{{Name: "red.png"}, {Name: "blue.png"}},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Composite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			composite, err := dbRepo.GetImageMetadataByUserIDAndPath(context.Background(), userID, tt.path)
+			if err != nil {
+				t.Fatalf("Composite() did not store the image: %v", err)
+			}
+			if composite.Properties.Width != 64 || composite.Properties.Height != 32 {
+				t.Fatalf("Composite() image size = %dx%d, want 64x32", composite.Properties.Width, composite.Properties.Height)
+			}
+		})
+	}
+}
+
+func TestImagesService_GetAnalysis(t *testing.T) {
+	userID := uuid.New()
+	service, dbRepo, _ := newTestService()
+
+	imageMetadata, err := service.upload(context.Background(), userID, "red.png", "", generateTestImage(t, color.NRGBA{R: 255, A: 255}))
+	if err != nil {
+		t.Fatalf("failed to upload test image: %v", err)
+	}
+
+	// The job is never handed to the runner, so it stays pending like a job waiting in the queue.
+	job, err := dbRepo.CreateJob(context.Background(), userID, domain.JobTypeAnalysis, domain.JobSource{
+		ImageID:     uuid.NullUUID{UUID: imageMetadata.ID, Valid: true},
+		ContentHash: imageMetadata.Hashes.ContentHash,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test job: %v", err)
+	}
+
+	for range 3 {
+		analysis, jobID, err := service.GetAnalysis(userID, "red.png")
+		if err != nil {
+			t.Fatalf("GetAnalysis() error = %v", err)
+		}
+		if analysis != nil || jobID != job.ID {
+			t.Fatalf("GetAnalysis() = %v, %v, want the pending job %v", analysis, jobID, job.ID)
+		}
+	}
+
+	dbRepo.jobs[job.ID].Status = domain.JobStatusFailed
+
+	_, jobID, err := service.GetAnalysis(userID, "red.png")
+	if err != nil {
+		t.Fatalf("GetAnalysis() error = %v", err)
+	}
+	if jobID == uuid.Nil || jobID == job.ID {
+		t.Fatalf("GetAnalysis() job = %v, want a new job", jobID)
+	}
+	service.Wait()
+}
+
+func TestImagesService_startJob_queueFull(t *testing.T) {
+	userID := uuid.New()
+	service, dbRepo, _ := newTestService()
+	service.jobRunner = newJobRunner(0, 0)
+
+	_, err := service.startJob(userID, domain.JobTypeAnalysis, domain.JobSource{}, func(context.Context) (*domain.ImageMetadata, error) {
+		return nil, nil
+	})
+
+	var commonErr commonerrors.Error
+	if !errors.As(err, &commonErr) || commonErr.Type() != commonerrors.Unavailable {
+		t.Fatalf("startJob() error = %v, want an unavailable error", err)
+	}
+	if len(dbRepo.jobs) != 0 {
+		t.Fatalf("startJob() left %d jobs behind, want none", len(dbRepo.jobs))
+	}
+}
@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/domain"
+	"time"
+)
+
+// CreateFolder creates the folder at the path along with any missing folders above it.
+func (s *ImagesService) CreateFolder(userID uuid.UUID, path string) error {
+	err := domain.ValidateFolderPath(path)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid folder path: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	folder, err := s.getFolder(ctx, userID, path)
+	if err != nil {
+		return err
+	}
+	if folder != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("folder %s already exists", path))
+	}
+
+	err = s.imagesDBRepo.CreateFolder(ctx, userID, path)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error creating folder in database: %v", err))
+	}
+
+	return nil
+}
+
+// GetFolders returns the folders in the folder at the path, or all folders below it if recursive is set, along with
+// the number of images in each of them.
+func (s *ImagesService) GetFolders(userID uuid.UUID, path string, recursive bool) ([]*domain.Folder, error) {
+	err := domain.ValidateFolderPath(path)
+	if err != nil {
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid folder path: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if path != domain.RootFolder {
+		folder, err := s.getFolder(ctx, userID, path)
+		if err != nil {
+			return nil, err
+		}
+		if folder == nil {
+			return nil, commonerrors.NewInvalidInput(fmt.Sprintf("folder %s does not exist", path))
+		}
+	}
+
+	folders, err := s.imagesDBRepo.GetFoldersByUserID(ctx, userID, path, recursive)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading folders from database: %v", err))
+	}
+
+	return folders, nil
+}
+
+// MoveFolder moves or renames the folder, together with everything in it. Nothing may exist at the new path yet.
+func (s *ImagesService) MoveFolder(userID uuid.UUID, oldPath, newPath string) error {
+	err := domain.ValidateFolderPath(oldPath)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid folder path: %v", err))
+	}
+
+	err = domain.ValidateFolderPath(newPath)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid new folder path: %v", err))
+	}
+
+	err = domain.ValidateFolderMove(oldPath, newPath)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid folder move: %v", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	folder, err := s.getFolder(ctx, userID, oldPath)
+	if err != nil {
+		return err
+	}
+	if folder == nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("folder %s does not exist", oldPath))
+	}
+
+	folder, err = s.getFolder(ctx, userID, newPath)
+	if err != nil {
+		return err
+	}
+	if folder != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("folder %s already exists", newPath))
+	}
+
+	err = s.imagesDBRepo.MoveFolder(ctx, userID, oldPath, newPath)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error moving folder in database: %v", err))
+	}
+
+	return nil
+}
+
+// DeleteFolder deletes the folder along with its subfolders. Folders that still contain images, directly or in a
+// subfolder, cannot be deleted.
+func (s *ImagesService) DeleteFolder(userID uuid.UUID, path string) error {
+	err := domain.ValidateFolderPath(path)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid folder path: %v", err))
+	}
+
+	if path == domain.RootFolder {
+		return commonerrors.NewInvalidInput("root folder cannot be deleted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	folder, err := s.getFolder(ctx, userID, path)
+	if err != nil {
+		return err
+	}
+	if folder == nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("folder %s does not exist", path))
+	}
+	if folder.TotalImageCount > 0 {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("folder %s is not empty", path))
+	}
+
+	err = s.imagesDBRepo.DeleteFolder(ctx, userID, path)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error deleting folder from database: %v", err))
+	}
+
+	return nil
+}
+
+// getFolder returns nil if the user has no folder at the path.
+func (s *ImagesService) getFolder(ctx context.Context, userID uuid.UUID, path string) (*domain.Folder, error) {
+	folder, err := s.imagesDBRepo.GetFolderByUserIDAndPath(ctx, userID, path)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading folder from database: %v", err))
+	}
+
+	return folder, nil
+}
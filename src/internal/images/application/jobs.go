@@ -0,0 +1,330 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/domain"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	jobWorkerCount = 2
+	jobQueueSize   = 20
+	jobTimeout     = 5 * time.Minute
+)
+
+// Sheets cover many images of a user, which can take far longer than a request is allowed to, so they are generated by
+// jobs running in the background. The user gets the job ID straight away and polls the job until it is done.
+
+func (s *ImagesService) CreateSpriteSheet(
+	userID uuid.UUID,
+	name,
+	description string,
+	imageNames []string,
+	padding,
+	maxSize int,
+) (uuid.UUID, error) {
+	err := validateSheet(name, description, imageNames)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if padding < 0 || maxSize < 0 {
+		return uuid.Nil, commonerrors.NewInvalidInput("padding and max size cannot be negative")
+	}
+
+	return s.startJob(userID, domain.JobTypeSpriteSheet, domain.JobSource{}, func(ctx context.Context) (*domain.ImageMetadata, error) {
+		return s.createSheet(ctx, userID, name, description, imageNames, false,
+			func(imagesBytes [][]byte, names []string) ([]byte, *domain.SheetDocument, error) {
+				return s.transformationsService.CreateSpriteSheet(imagesBytes, names, padding, maxSize)
+			},
+		)
+	})
+}
+
+func (s *ImagesService) CreateContactSheet(
+	userID uuid.UUID,
+	name,
+	description string,
+	imageNames []string,
+	columns int,
+) (uuid.UUID, error) {
+	err := validateSheet(name, description, imageNames)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if columns < 0 {
+		return uuid.Nil, commonerrors.NewInvalidInput("columns cannot be negative")
+	}
+
+	// Contact sheets are made of thumbnails, so the previews are used instead of the full images.
+	return s.startJob(userID, domain.JobTypeContactSheet, domain.JobSource{}, func(ctx context.Context) (*domain.ImageMetadata, error) {
+		return s.createSheet(ctx, userID, name, description, imageNames, true,
+			func(imagesBytes [][]byte, names []string) ([]byte, *domain.SheetDocument, error) {
+				return s.transformationsService.CreateContactSheet(imagesBytes, names, columns)
+			},
+		)
+	})
+}
+
+// GetJob returns the job along with the image it produced or analysed and the document describing the image, if there
+// are any.
+func (s *ImagesService) GetJob(userID, id uuid.UUID) (*domain.Job, *domain.ImageMetadata, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job, err := s.imagesDBRepo.GetJobByUserIDAndID(ctx, userID, id)
+	if err != nil {
+		return nil, nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading job from database: %v", err))
+	}
+
+	if !job.ImageID.Valid {
+		return job, nil, nil, nil
+	}
+
+	imageMetadata, err := s.imagesDBRepo.GetImageMetadataByID(ctx, job.ImageID.UUID)
+	if err != nil {
+		return nil, nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+	}
+
+	// Analyses are kept in the database rather than next to the image, as they are replaced whenever the image changes.
+	if job.Type == domain.JobTypeAnalysis {
+		analysis, err := s.imagesDBRepo.GetImageAnalysis(ctx, imageMetadata.ID, imageMetadata.Hashes.ContentHash)
+		if err != nil {
+			return nil, nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading image analysis from database: %v", err))
+		}
+		if analysis == nil {
+			return job, imageMetadata, nil, nil
+		}
+
+		document, err := json.Marshal(analysis)
+		if err != nil {
+			return nil, nil, nil, commonerrors.NewInternal(fmt.Sprintf("error marshalling image analysis: %v", err))
+		}
+
+		return job, imageMetadata, document, nil
+	}
+
+	document, err := s.imagesStorageRepo.DownloadImage(ctx, domain.CreateDocumentObjectName(imageMetadata.ID))
+	if err != nil {
+		return nil, nil, nil, commonerrors.NewInternal(fmt.Sprintf("error downloading document from storage: %v", err))
+	}
+
+	return job, imageMetadata, document, nil
+}
+
+func validateSheet(name, description string, imageNames []string) error {
+	_, _, err := validateImagePath(name)
+	if err != nil {
+		return err
+	}
+
+	err = domain.ValidateDescription(description)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid image description: %v", err))
+	}
+
+	err = domain.ValidateSheetImages(imageNames)
+	if err != nil {
+		return commonerrors.NewInvalidInput(fmt.Sprintf("invalid sheet images: %v", err))
+	}
+
+	return nil
+}
+
+// createSheet renders a sheet out of the given images, or out of the latest images of the user if none are given, and
+// stores it as a new image together with its document.
+func (s *ImagesService) createSheet(
+	ctx context.Context,
+	userID uuid.UUID,
+	name,
+	description string,
+	imageNames []string,
+	usePreviews bool,
+	render func(imagesBytes [][]byte, names []string) ([]byte, *domain.SheetDocument, error),
+) (*domain.ImageMetadata, error) {
+	var imagesMetadata []*domain.ImageMetadata
+	if len(imageNames) == 0 {
+		listing := domain.ImagesListing{
+			Sort:  domain.ImagesSortCreatedAt,
+			Order: domain.SortOrderDescending,
+			Limit: domain.MaxSheetImages,
+		}
+		var err error
+		imagesMetadata, _, err = s.imagesDBRepo.ListImagesMetadataByUserID(ctx, userID, domain.ImagesFilter{}, listing)
+		if err != nil {
+			return nil, commonerrors.NewInternal(fmt.Sprintf("error reading images metadata from database: %v", err))
+		}
+		imagesMetadata, _, _ = domain.PaginateImages(imagesMetadata, listing)
+	}
+	for _, imageName := range imageNames {
+		imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, imageName)
+		if err != nil {
+			return nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+		}
+		imagesMetadata = append(imagesMetadata, imageMetadata)
+	}
+
+	if len(imagesMetadata) == 0 {
+		return nil, commonerrors.NewInvalidInput("there are no images to put on the sheet")
+	}
+
+	imagesBytes := make([][]byte, len(imagesMetadata))
+	names := make([]string, len(imagesMetadata))
+	for i, imageMetadata := range imagesMetadata {
+		objectName := domain.CreateFullImageObjectName(imageMetadata.ID)
+		if usePreviews {
+			objectName = domain.CreatePreviewImageObjectName(imageMetadata.ID)
+		}
+
+		var err error
+		imagesBytes[i], err = s.getImageBytes(ctx, objectName)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = imageMetadata.Name
+	}
+
+	sheetBytes, document, err := render(imagesBytes, names)
+	if err != nil {
+		return nil, err
+	}
+
+	imageMetadata, err := s.upload(ctx, userID, name, description, sheetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	document.Image = imageMetadata.Name
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error marshalling document: %v", err))
+	}
+
+	err = s.imagesStorageRepo.UploadImage(ctx, domain.CreateDocumentObjectName(imageMetadata.ID), documentBytes)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error uploading document to storage: %v", err))
+	}
+
+	return imageMetadata, nil
+}
+
+// startJob records a new job and hands it over to the job runner. If the runner is too busy to accept it, the job is
+// deleted again, since its ID is never returned, and the caller is asked to retry later.
+func (s *ImagesService) startJob(
+	userID uuid.UUID,
+	jobType domain.JobType,
+	source domain.JobSource,
+	run func(ctx context.Context) (*domain.ImageMetadata, error),
+) (uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job, err := s.imagesDBRepo.CreateJob(ctx, userID, jobType, source)
+	if err != nil {
+		return uuid.Nil, commonerrors.NewInternal(fmt.Sprintf("error creating job in database: %v", err))
+	}
+
+	accepted := s.jobRunner.submit(func() {
+		s.runJob(job, run)
+	})
+	if !accepted {
+		err = s.imagesDBRepo.DeleteJob(ctx, job.ID)
+		if err != nil {
+			slog.Error("Job error", "job", job.ID, "error", err)
+		}
+
+		return uuid.Nil, commonerrors.NewUnavailable("too many jobs are running, try again later")
+	}
+
+	return job.ID, nil
+}
+
+func (s *ImagesService) runJob(job *domain.Job, run func(ctx context.Context) (*domain.ImageMetadata, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	var imageMetadata *domain.ImageMetadata
+	err := s.imagesDBRepo.UpdateJob(ctx, job.ID, domain.JobStatusRunning, uuid.NullUUID{}, "")
+	if err == nil {
+		imageMetadata, err = run(ctx)
+	}
+
+	// The job may have used up its time, so the result is recorded with a context of its own.
+	updateCtx, updateCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer updateCancel()
+
+	// A job that failed to be marked as running is marked as failed too, so that it is never left pending.
+	if err != nil {
+		slog.Error("Job error", "job", job.ID, "error", err)
+
+		err = s.imagesDBRepo.UpdateJob(updateCtx, job.ID, domain.JobStatusFailed, uuid.NullUUID{}, jobErrorMessage(err))
+		if err != nil {
+			slog.Error("Job error", "job", job.ID, "error", err)
+		}
+		return
+	}
+
+	imageID := uuid.NullUUID{UUID: imageMetadata.ID, Valid: true}
+	err = s.imagesDBRepo.UpdateJob(updateCtx, job.ID, domain.JobStatusCompleted, imageID, "")
+	if err != nil {
+		slog.Error("Job error", "job", job.ID, "error", err)
+	}
+}
+
+// jobErrorMessage returns the error of a failed job as it is shown to the user. Like the HTTP responses, it only
+// reveals the message of invalid input, while the details of other errors are only logged.
+func jobErrorMessage(err error) string {
+	var commonError commonerrors.Error
+	if errors.As(err, &commonError) && commonError.Type() == commonerrors.InvalidInput {
+		return commonError.Error()
+	}
+
+	return "internal error"
+}
+
+// jobRunner executes jobs on a fixed number of goroutines. Unlike the transformations, which are awaited by a request,
+// nobody waits for a job, so a full queue is reported to the caller instead of blocking it.
+type jobRunner struct {
+	queue chan func()
+	wg    sync.WaitGroup
+}
+
+func newJobRunner(workerCount, queueSize int) *jobRunner {
+	r := &jobRunner{
+		queue: make(chan func(), queueSize),
+	}
+
+	for range workerCount {
+		go func() {
+			for job := range r.queue {
+				job()
+				r.wg.Done()
+			}
+		}()
+	}
+
+	return r
+}
+
+func (r *jobRunner) submit(job func()) bool {
+	r.wg.Add(1)
+	select {
+	case r.queue <- job:
+		return true
+	default:
+		r.wg.Done()
+		return false
+	}
+}
+
+func (r *jobRunner) wait() {
+	r.wg.Wait()
+}
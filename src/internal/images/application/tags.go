@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/domain"
+	"time"
+)
+
+// AddTags tags the images, creating the tags the user does not have yet. Tagging an image with a tag it already carries
+// changes nothing.
+func (s *ImagesService) AddTags(userID uuid.UUID, imageNames, tags []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	imageIDs, tags, err := s.prepareTagging(ctx, userID, imageNames, tags)
+	if err != nil {
+		return err
+	}
+
+	err = s.imagesDBRepo.AddImageTags(ctx, userID, imageIDs, tags)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error adding tags in database: %v", err))
+	}
+
+	return nil
+}
+
+func (s *ImagesService) RemoveTags(userID uuid.UUID, imageNames, tags []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	imageIDs, tags, err := s.prepareTagging(ctx, userID, imageNames, tags)
+	if err != nil {
+		return err
+	}
+
+	err = s.imagesDBRepo.RemoveImageTags(ctx, userID, imageIDs, tags)
+	if err != nil {
+		return commonerrors.NewInternal(fmt.Sprintf("error removing tags in database: %v", err))
+	}
+
+	return nil
+}
+
+func (s *ImagesService) GetTags(userID uuid.UUID) ([]*domain.TagCount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tags, err := s.imagesDBRepo.GetTagsByUserID(ctx, userID)
+	if err != nil {
+		return nil, commonerrors.NewInternal(fmt.Sprintf("error reading tags from database: %v", err))
+	}
+
+	return tags, nil
+}
+
+// prepareTagging validates the images and tags of a tagging request and looks up the IDs of the images.
+func (s *ImagesService) prepareTagging(
+	ctx context.Context,
+	userID uuid.UUID,
+	imageNames,
+	tags []string,
+) ([]uuid.UUID, []string, error) {
+	err := domain.ValidateTaggedImages(imageNames)
+	if err != nil {
+		return nil, nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid images: %v", err))
+	}
+
+	tags = domain.NormalizeTags(tags)
+	err = domain.ValidateTags(tags)
+	if err != nil {
+		return nil, nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid tags: %v", err))
+	}
+
+	imageIDs := make([]uuid.UUID, len(imageNames))
+	for i, imageName := range imageNames {
+		imageMetadata, err := s.imagesDBRepo.GetImageMetadataByUserIDAndPath(ctx, userID, imageName)
+		if err != nil {
+			return nil, nil, commonerrors.NewInternal(fmt.Sprintf("error reading image metadata from database: %v", err))
+		}
+		imageIDs[i] = imageMetadata.ID
+	}
+
+	return imageIDs, tags, nil
+}
@@ -0,0 +1,217 @@
+package transformations
+
+import (
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"math"
+)
+
+// standardLuminanceQuantization is the luminance quantization table suggested by the JPEG specification, which most
+// encoders scale according to the requested quality.
+var standardLuminanceQuantization = [64]int{
+	16, 11, 10, 16, 24, 40, 51, 61,
+	12, 12, 14, 19, 26, 58, 60, 55,
+	14, 13, 16, 24, 40, 57, 69, 56,
+	14, 17, 22, 29, 51, 87, 80, 62,
+	18, 22, 37, 56, 68, 109, 103, 77,
+	24, 35, 55, 64, 81, 104, 113, 92,
+	49, 64, 78, 87, 103, 121, 120, 101,
+	72, 92, 95, 98, 112, 100, 103, 99,
+}
+
+// zigzag maps the order in which the entries of a quantization table are stored to their position in the table.
+var zigzag = [64]int{
+	0, 1, 8, 16, 9, 2, 3, 10, 17, 24, 32, 25, 18, 11, 4, 5,
+	12, 19, 26, 33, 40, 48, 41, 34, 27, 20, 13, 6, 7, 14, 21, 28,
+	35, 42, 49, 56, 57, 50, 43, 36, 29, 22, 15, 23, 30, 37, 44, 51,
+	58, 59, 52, 45, 38, 31, 39, 46, 53, 60, 61, 54, 47, 55, 62, 63,
+}
+
+// Analyze computes statistics describing the content and the quality of the image. Unlike the hashes and the palette,
+// the statistics depend on the fine detail of the image, so the full image is analysed. Animations are represented by
+// their first frame.
+func (s *Service) Analyze(imageBytes []byte) (*domain.ImageAnalysis, error) {
+	img, _, err := deserialize(imageBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := analyze(img)
+	if quality, ok := estimateJPEGQuality(imageBytes); ok {
+		analysis.JPEGQuality = &quality
+	}
+
+	return analysis, nil
+}
+
+func analyze(img image.Image) *domain.ImageAnalysis {
+	src := toNRGBA(img)
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	analysis := &domain.ImageAnalysis{Width: width, Height: height}
+
+	// The luminance is kept as bytes, as a float per pixel would take a lot of memory for large images.
+	levels := make([]uint8, width*height)
+	var sum, sumOfSquares float64
+	for y := range height {
+		for x := range width {
+			c := src.NRGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			l := luminance(c)
+			sum += l
+			sumOfSquares += l * l
+
+			value := uint8(math.Round(l))
+			levels[y*width+x] = value
+			analysis.Histograms.Red[c.R]++
+			analysis.Histograms.Green[c.G]++
+			analysis.Histograms.Blue[c.B]++
+			analysis.Histograms.Alpha[c.A]++
+			analysis.Histograms.Luminance[value]++
+			if c.A < 255 {
+				analysis.UsesAlpha = true
+			}
+		}
+	}
+
+	count := float64(width * height)
+	if count == 0 {
+		return analysis
+	}
+
+	analysis.LuminanceMean = sum / count
+	analysis.LuminanceStdDev = math.Sqrt(max(0, sumOfSquares/count-analysis.LuminanceMean*analysis.LuminanceMean))
+	analysis.ClippedShadows = 100 * float64(analysis.Histograms.Luminance[0]) / count
+	analysis.ClippedHighlights = 100 * float64(analysis.Histograms.Luminance[255]) / count
+	analysis.Sharpness = laplacianVariance(levels, width, height)
+	analysis.Noise = estimateNoise(levels, width, height)
+
+	return analysis
+}
+
+// laplacianVariance measures the sharpness of the image as the variance of its Laplacian. Edges give a strong response
+// to the Laplacian, so the variance is high when there are many sharp edges and low when the image is blurry.
+func laplacianVariance(luminance []uint8, width, height int) float64 {
+	if width < 3 || height < 3 {
+		return 0
+	}
+
+	var sum, sumOfSquares float64
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			i := y*width + x
+			v := float64(luminance[i-width]) + float64(luminance[i-1]) + float64(luminance[i+1]) +
+				float64(luminance[i+width]) - 4*float64(luminance[i])
+			sum += v
+			sumOfSquares += v * v
+		}
+	}
+
+	count := float64((width - 2) * (height - 2))
+	mean := sum / count
+
+	return sumOfSquares/count - mean*mean
+}
+
+// estimateNoise estimates the standard deviation of the noise as described by Immerkær in "Fast Noise Variance
+// Estimation". The kernel is the difference of two Laplacians, which cancels out most of the structure of the image and
+// leaves the noise behind.
+func estimateNoise(luminance []uint8, width, height int) float64 {
+	if width < 3 || height < 3 {
+		return 0
+	}
+
+	var sum float64
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			i := y*width + x
+			corners := int(luminance[i-width-1]) + int(luminance[i-width+1]) +
+				int(luminance[i+width-1]) + int(luminance[i+width+1])
+			edges := int(luminance[i-width]) + int(luminance[i-1]) + int(luminance[i+1]) + int(luminance[i+width])
+			v := corners - 2*edges + 4*int(luminance[i])
+			sum += math.Abs(float64(v))
+		}
+	}
+
+	return sum * math.Sqrt(math.Pi/2) / (6 * float64((width-2)*(height-2)))
+}
+
+// estimateJPEGQuality reverses the scaling of the standard luminance quantization table done by encoders following the
+// reference implementation of the Independent JPEG Group. It reports false if the data is not a JPEG image or has no
+// luminance table.
+func estimateJPEGQuality(data []byte) (int, bool) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return 0, false
+	}
+
+	// The tables are defined by DQT segments, which come before the start of the scan.
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return 0, false
+		}
+		marker := data[i+1]
+		if marker == 0xff {
+			i++
+			continue
+		}
+
+		length := int(data[i+2])<<8 | int(data[i+3])
+		if marker == 0xda || length < 2 || i+2+length > len(data) {
+			return 0, false
+		}
+
+		if marker == 0xdb {
+			segment := data[i+4 : i+2+length]
+			for len(segment) > 0 {
+				precision, id := segment[0]>>4, segment[0]&0x0f
+				size := 64
+				if precision == 1 {
+					size = 128
+				}
+				if len(segment) < 1+size {
+					return 0, false
+				}
+
+				if id == 0 {
+					return qualityFromTable(segment[1:1+size], precision == 1), true
+				}
+				segment = segment[1+size:]
+			}
+		}
+
+		i += 2 + length
+	}
+
+	return 0, false
+}
+
+func qualityFromTable(table []byte, wide bool) int {
+	// Low qualities push many entries to the largest value a table can hold, which would hide how much they were
+	// scaled, so such entries are left out unless all of them were capped.
+	var sum, standardSum, cappedSum, standardCappedSum float64
+	for i := range 64 {
+		value := int(table[i])
+		if wide {
+			value = int(table[2*i])<<8 | int(table[2*i+1])
+		}
+
+		if value >= 255 {
+			cappedSum += float64(value)
+			standardCappedSum += float64(standardLuminanceQuantization[zigzag[i]])
+			continue
+		}
+		sum += float64(value)
+		standardSum += float64(standardLuminanceQuantization[zigzag[i]])
+	}
+	if standardSum == 0 {
+		sum, standardSum = cappedSum, standardCappedSum
+	}
+
+	// The quality is turned into a scaling factor in percent, which is linear above 50 and hyperbolic below.
+	scale := 100 * sum / standardSum
+	quality := 5000 / scale
+	if scale <= 100 {
+		quality = (200 - scale) / 2
+	}
+
+	return min(100, max(1, int(math.Round(quality))))
+}
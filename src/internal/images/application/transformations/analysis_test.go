@@ -0,0 +1,121 @@
+package transformations
+
+import (
+	"bytes"
+	"github.com/disintegration/imaging"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+)
+
+func Test_analyze(t *testing.T) {
+	checkerboard := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	for y := range 16 {
+		for x := range 16 {
+			if (x+y)%2 == 0 {
+				checkerboard.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+			} else {
+				checkerboard.SetNRGBA(x, y, color.NRGBA{A: 255})
+			}
+		}
+	}
+
+	tests := []struct {
+		name              string
+		img               image.Image
+		wantMean          float64
+		wantStdDev        float64
+		wantShadows       float64
+		wantHighlights    float64
+		wantSharp         bool
+		wantNoise         bool
+		wantUsesAlpha     bool
+		wantRedHistogram0 int
+	}{
+		{
+			name:              "Black",
+			img:               imaging.New(10, 10, color.NRGBA{A: 255}),
+			wantShadows:       100,
+			wantRedHistogram0: 100,
+		},
+		{
+			name:     "Gray",
+			img:      imaging.New(10, 10, color.NRGBA{R: 128, G: 128, B: 128, A: 255}),
+			wantMean: 128,
+		},
+		{
+			name:              "Checkerboard",
+			img:               checkerboard,
+			wantMean:          127.5,
+			wantStdDev:        127.5,
+			wantShadows:       50,
+			wantHighlights:    50,
+			wantSharp:         true,
+			wantNoise:         true,
+			wantRedHistogram0: 128,
+		},
+		{
+			name:              "Transparent",
+			img:               imaging.New(5, 4, color.NRGBA{}),
+			wantShadows:       100,
+			wantUsesAlpha:     true,
+			wantRedHistogram0: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := analyze(tt.img)
+			if math.Abs(got.LuminanceMean-tt.wantMean) > 0.5 {
+				t.Errorf("analyze() luminance mean = %v, want %v", got.LuminanceMean, tt.wantMean)
+			}
+			if math.Abs(got.LuminanceStdDev-tt.wantStdDev) > 0.5 {
+				t.Errorf("analyze() luminance std dev = %v, want %v", got.LuminanceStdDev, tt.wantStdDev)
+			}
+			if got.ClippedShadows != tt.wantShadows || got.ClippedHighlights != tt.wantHighlights {
+				t.Errorf("analyze() clipping = %v/%v, want %v/%v",
+					got.ClippedShadows, got.ClippedHighlights, tt.wantShadows, tt.wantHighlights)
+			}
+			if (got.Sharpness > 0) != tt.wantSharp {
+				t.Errorf("analyze() sharpness = %v, want sharp %v", got.Sharpness, tt.wantSharp)
+			}
+			if (got.Noise > 0) != tt.wantNoise {
+				t.Errorf("analyze() noise = %v, want noise %v", got.Noise, tt.wantNoise)
+			}
+			if got.UsesAlpha != tt.wantUsesAlpha {
+				t.Errorf("analyze() uses alpha = %v, want %v", got.UsesAlpha, tt.wantUsesAlpha)
+			}
+			if got.Histograms.Red[0] != tt.wantRedHistogram0 {
+				t.Errorf("analyze() red histogram[0] = %v, want %v", got.Histograms.Red[0], tt.wantRedHistogram0)
+			}
+		})
+	}
+}
+
+func Test_estimateJPEGQuality(t *testing.T) {
+	img := imaging.New(32, 32, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+
+	for _, quality := range []int{10, 50, 75, 90, 100} {
+		var buf bytes.Buffer
+		err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+		if err != nil {
+			t.Fatalf("jpeg.Encode() error = %v", err)
+		}
+
+		got, ok := estimateJPEGQuality(buf.Bytes())
+		if !ok || math.Abs(float64(got-quality)) > 2 {
+			t.Errorf("estimateJPEGQuality() = %v, %v, want %v", got, ok, quality)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	if _, ok := estimateJPEGQuality(buf.Bytes()); ok {
+		t.Errorf("estimateJPEGQuality() of a PNG image should not report a quality")
+	}
+}
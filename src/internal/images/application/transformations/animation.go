@@ -0,0 +1,164 @@
+package transformations
+
+import (
+	"fmt"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/draw"
+	"image/gif"
+	"math"
+)
+
+// Browsers treat delays below 2 hundredths of a second as if they were 10, which would slow an animation down instead
+// of speeding it up.
+const minFrameDelay = 2
+
+// maxAnimationPixels limits the pixels of all frames of an animation together. Every frame is coalesced into a full
+// picture, so a GIF of many tiny frames on a large canvas would otherwise take far more memory than its size suggests.
+const maxAnimationPixels = 2 * domain.MaxImageDimension * domain.MaxImageDimension
+
+// animation holds the frames of an animated GIF together with their timing. The frames are coalesced, meaning that
+// every frame is the full picture shown at that moment rather than just the part that changed, so that they can be
+// transformed one by one like still images.
+type animation struct {
+	frames    []image.Image
+	delays    []int
+	disposals []byte
+	loopCount int
+}
+
+// animationBounds returns the canvas the frames of the GIF are coalesced on, checking that the coalesced frames stay
+// within the limits.
+func animationBounds(g *gif.GIF) (image.Rectangle, error) {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	for _, frame := range g.Image {
+		bounds = bounds.Union(frame.Bounds())
+	}
+
+	err := validateImageDimensions(bounds.Dx(), bounds.Dy())
+	if err != nil {
+		return image.Rectangle{}, err
+	}
+
+	if len(g.Image)*bounds.Dx()*bounds.Dy() > maxAnimationPixels {
+		return image.Rectangle{}, fmt.Errorf(
+			"%d frames of %dx%d exceed the maximum of %d pixels", len(g.Image), bounds.Dx(), bounds.Dy(), maxAnimationPixels,
+		)
+	}
+
+	return bounds, nil
+}
+
+// newAnimation coalesces the frames of the GIF by replaying them the way a viewer would. The disposal methods are kept
+// as they are, since for coalesced frames they still describe what shows through the transparent parts of the next one.
+func newAnimation(g *gif.GIF, bounds image.Rectangle) *animation {
+	anim := &animation{
+		frames:    make([]image.Image, len(g.Image)),
+		delays:    make([]int, len(g.Image)),
+		disposals: make([]byte, len(g.Image)),
+		loopCount: g.LoopCount,
+	}
+
+	canvas := image.NewNRGBA(bounds)
+	for i, frame := range g.Image {
+		if i < len(g.Delay) {
+			anim.delays[i] = g.Delay[i]
+		}
+		if i < len(g.Disposal) {
+			anim.disposals[i] = g.Disposal[i]
+		}
+
+		var previous *image.NRGBA
+		if anim.disposals[i] == gif.DisposalPrevious {
+			previous = cloneNRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		anim.frames[i] = cloneNRGBA(canvas)
+
+		switch anim.disposals[i] {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	return anim
+}
+
+func cloneNRGBA(img *image.NRGBA) *image.NRGBA {
+	clone := image.NewNRGBA(img.Bounds())
+	copy(clone.Pix, img.Pix)
+	return clone
+}
+
+func isAnimationTransformation(transformationType domain.TransformationType) bool {
+	switch transformationType {
+	case domain.ExtractFrame, domain.ChangeSpeed, domain.TrimFrames:
+		return true
+	default:
+		return false
+	}
+}
+
+func applyAnimationTransformation(packet *transformationPacket, t domain.Transformation) error {
+	if packet.animation == nil {
+		return fmt.Errorf("image is not animated")
+	}
+
+	switch t.Type {
+	case domain.ExtractFrame:
+		return extractFrame(packet, t.Options)
+	case domain.ChangeSpeed:
+		return changeSpeed(packet.animation, t.Options)
+	case domain.TrimFrames:
+		return trimFrames(packet.animation, t.Options)
+	default:
+		return fmt.Errorf("unsupported transformation type: %v", t.Type)
+	}
+}
+
+// extractFrame replaces the animation with one of its frames. The frame is stored as PNG, since it no longer needs to
+// be limited to the palette of a GIF.
+func extractFrame(packet *transformationPacket, options map[domain.TransformationOptionType]float64) error {
+	index := int(options[domain.Frame])
+	if index < 0 || index >= len(packet.animation.frames) {
+		return fmt.Errorf("frame must be between 0 and %d", len(packet.animation.frames)-1)
+	}
+
+	packet.img = packet.animation.frames[index]
+	packet.animation = nil
+	packet.format = "png"
+
+	return nil
+}
+
+// changeSpeed divides every delay by the factor, so a factor of 2 plays the animation twice as fast.
+func changeSpeed(anim *animation, options map[domain.TransformationOptionType]float64) error {
+	factor, ok := options[domain.Factor]
+	if !ok || factor <= 0 || factor > 100 {
+		return fmt.Errorf("factor must be between 0 and 100")
+	}
+
+	for i, delay := range anim.delays {
+		anim.delays[i] = max(minFrameDelay, int(math.Round(float64(delay)/factor)))
+	}
+
+	return nil
+}
+
+// trimFrames keeps the frames from start up to, but not including, end. Unless given, end is the number of frames.
+func trimFrames(anim *animation, options map[domain.TransformationOptionType]float64) error {
+	start := int(options[domain.Start])
+	end := int(optionOrDefault(options, domain.End, float64(len(anim.frames))))
+	if start < 0 || end > len(anim.frames) || start >= end {
+		return fmt.Errorf("start and end must satisfy 0 <= start < end <= %d", len(anim.frames))
+	}
+
+	anim.frames = anim.frames[start:end]
+	anim.delays = anim.delays[start:end]
+	anim.disposals = anim.disposals[start:end]
+
+	return nil
+}
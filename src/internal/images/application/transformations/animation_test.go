@@ -0,0 +1,312 @@
+package transformations
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+func generateTestAnimation(t *testing.T) []byte {
+	palette := color.Palette{color.Transparent, color.NRGBA{R: 255, A: 255}, color.NRGBA{B: 255, A: 255}}
+
+	first := image.NewPaletted(image.Rect(0, 0, 4, 4), palette)
+	for i := range first.Pix {
+		first.Pix[i] = 1
+	}
+	// The second frame only covers the top left corner, the rest has to show the first frame.
+	second := image.NewPaletted(image.Rect(0, 0, 2, 2), palette)
+	for i := range second.Pix {
+		second.Pix[i] = 2
+	}
+	third := image.NewPaletted(image.Rect(2, 2, 4, 4), palette)
+
+	var buf bytes.Buffer
+	err := gif.EncodeAll(&buf, &gif.GIF{
+		Image:     []*image.Paletted{first, second, third},
+		Delay:     []int{10, 20, 30},
+		Disposal:  []byte{gif.DisposalNone, gif.DisposalNone, gif.DisposalBackground},
+		LoopCount: 3,
+		Config:    image.Config{Width: 4, Height: 4, ColorModel: palette},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode test animation: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func Test_deserializeAnimation(t *testing.T) {
+	anim, err := deserializeAnimation(generateTestAnimation(t))
+	if err != nil {
+		t.Fatalf("deserializeAnimation() error = %v", err)
+	}
+
+	if len(anim.frames) != 3 {
+		t.Fatalf("deserializeAnimation() frames = %d, want 3", len(anim.frames))
+	}
+
+	for _, frame := range anim.frames {
+		if frame.Bounds() != image.Rect(0, 0, 4, 4) {
+			t.Errorf("deserializeAnimation() frame bounds = %v, want %v", frame.Bounds(), image.Rect(0, 0, 4, 4))
+		}
+	}
+
+	if got := color.NRGBAModel.Convert(anim.frames[1].At(0, 0)); got != (color.NRGBA{B: 255, A: 255}) {
+		t.Errorf("deserializeAnimation() second frame at (0, 0) = %v, want blue", got)
+	}
+	if got := color.NRGBAModel.Convert(anim.frames[1].At(3, 3)); got != (color.NRGBA{R: 255, A: 255}) {
+		t.Errorf("deserializeAnimation() second frame at (3, 3) = %v, want red from the first frame", got)
+	}
+}
+
+func Test_deserializeAnimation_limits(t *testing.T) {
+	palette := color.Palette{color.Transparent, color.NRGBA{R: 255, A: 255}}
+
+	// The frames are tiny, but every one of them is coalesced into a picture of the whole canvas.
+	encode := func(width, height, frames int) []byte {
+		g := &gif.GIF{Config: image.Config{Width: width, Height: height, ColorModel: palette}}
+		for range frames {
+			g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, 1, 1), palette))
+			g.Delay = append(g.Delay, 10)
+		}
+
+		var buf bytes.Buffer
+		err := gif.EncodeAll(&buf, g)
+		if err != nil {
+			t.Fatalf("failed to encode test animation: %v", err)
+		}
+
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"Largest canvas", encode(domain.MaxImageDimension, domain.MaxImageDimension, 2), false},
+		{"Canvas too wide", encode(domain.MaxImageDimension+1, 1, 1), true},
+		{"Canvas too high", encode(1, 65535, 1), true},
+		{"Too many frames", encode(domain.MaxImageDimension, domain.MaxImageDimension, 3), true},
+		{"Many frames on a small canvas", encode(64, 64, 1000), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := deserializeAnimation(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("deserializeAnimation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var commonErr commonerrors.Error
+			if err != nil && (!errors.As(err, &commonErr) || commonErr.Type() != commonerrors.InvalidInput) {
+				t.Errorf("deserializeAnimation() error = %v, want invalid input", err)
+			}
+		})
+	}
+}
+
+func Test_applyTransformations_animation(t *testing.T) {
+	tests := []struct {
+		name            string
+		transformations []domain.Transformation
+		wantFrames      int
+		wantDelays      []int
+		wantSize        image.Point
+		wantFormat      string
+		wantErr         bool
+	}{
+		{
+			name: "Resize every frame",
+			transformations: []domain.Transformation{
+				{Type: domain.Resize, Options: map[domain.TransformationOptionType]float64{domain.Width: 8, domain.Height: 8}},
+				{Type: domain.Grayscale},
+			},
+			wantFrames: 3,
+			wantDelays: []int{10, 20, 30},
+			wantSize:   image.Pt(8, 8),
+			wantFormat: "gif",
+			wantErr:    false,
+		},
+		{
+			name: "Change speed",
+			transformations: []domain.Transformation{
+				{Type: domain.ChangeSpeed, Options: map[domain.TransformationOptionType]float64{domain.Factor: 10}},
+			},
+			wantFrames: 3,
+			wantDelays: []int{2, 2, 3},
+			wantSize:   image.Pt(4, 4),
+			wantFormat: "gif",
+			wantErr:    false,
+		},
+		{
+			name: "Trim frames",
+			transformations: []domain.Transformation{
+				{Type: domain.TrimFrames, Options: map[domain.TransformationOptionType]float64{domain.Start: 1}},
+			},
+			wantFrames: 2,
+			wantDelays: []int{20, 30},
+			wantSize:   image.Pt(4, 4),
+			wantFormat: "gif",
+			wantErr:    false,
+		},
+		{
+			name: "Extract frame",
+			transformations: []domain.Transformation{
+				{Type: domain.ExtractFrame, Options: map[domain.TransformationOptionType]float64{domain.Frame: 2}},
+			},
+			wantSize:   image.Pt(4, 4),
+			wantFormat: "png",
+			wantErr:    false,
+		},
+		{
+			name: "Remove background color",
+			transformations: []domain.Transformation{
+				{Type: domain.RemoveBackground, Parameters: map[domain.TransformationParameterType]string{domain.Color: "#ff0000"}},
+			},
+			wantFrames: 3,
+			wantDelays: []int{10, 20, 30},
+			wantSize:   image.Pt(4, 4),
+			wantFormat: "gif",
+			wantErr:    false,
+		},
+		{
+			name: "Feathered background removal",
+			transformations: []domain.Transformation{
+				{
+					Type:       domain.RemoveBackground,
+					Options:    map[domain.TransformationOptionType]float64{domain.Feather: 10},
+					Parameters: map[domain.TransformationParameterType]string{domain.Color: "#ff0000"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Frame out of range",
+			transformations: []domain.Transformation{
+				{Type: domain.ExtractFrame, Options: map[domain.TransformationOptionType]float64{domain.Frame: 3}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty trim",
+			transformations: []domain.Transformation{
+				{Type: domain.TrimFrames, Options: map[domain.TransformationOptionType]float64{domain.Start: 2, domain.End: 2}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packet, err := assemble(generateTestAnimation(t), tt.transformations)
+			if err != nil {
+				t.Fatalf("assemble() error = %v", err)
+			}
+
+			err = applyTransformations(packet)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyTransformations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if packet.format != tt.wantFormat {
+				t.Errorf("applyTransformations() format = %v, want %v", packet.format, tt.wantFormat)
+			}
+
+			if packet.animation == nil {
+				if packet.img.Bounds().Size() != tt.wantSize {
+					t.Errorf("applyTransformations() size = %v, want %v", packet.img.Bounds().Size(), tt.wantSize)
+				}
+				return
+			}
+
+			data, err := serializeAnimation(packet.animation)
+			if err != nil {
+				t.Fatalf("serializeAnimation() error = %v", err)
+			}
+			g, err := gif.DecodeAll(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("gif.DecodeAll() error = %v", err)
+			}
+
+			if len(g.Image) != tt.wantFrames {
+				t.Errorf("applyTransformations() frames = %d, want %d", len(g.Image), tt.wantFrames)
+			}
+			for i, delay := range tt.wantDelays {
+				if g.Delay[i] != delay {
+					t.Errorf("applyTransformations() delay %d = %d, want %d", i, g.Delay[i], delay)
+				}
+			}
+			if g.Disposal[len(g.Disposal)-1] != gif.DisposalBackground {
+				t.Errorf("applyTransformations() disposal = %v, want %v", g.Disposal[len(g.Disposal)-1], gif.DisposalBackground)
+			}
+			if g.LoopCount != 3 {
+				t.Errorf("applyTransformations() loop count = %d, want 3", g.LoopCount)
+			}
+			if size := image.Pt(g.Config.Width, g.Config.Height); size != tt.wantSize {
+				t.Errorf("applyTransformations() size = %v, want %v", size, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestService_CreateVariants_animation(t *testing.T) {
+	variants, err := NewService().CreateVariants(generateTestAnimation(t), []int{2, 8})
+	if err != nil {
+		t.Fatalf("CreateVariants() error = %v", err)
+	}
+
+	// The second variant must not be affected by the resizing of the first one, even though they share the frames.
+	for i, wantSize := range []image.Point{image.Pt(2, 2), image.Pt(4, 4)} {
+		g, err := gif.DecodeAll(bytes.NewReader(variants[i]))
+		if err != nil {
+			t.Fatalf("gif.DecodeAll() error = %v", err)
+		}
+		if len(g.Image) != 3 {
+			t.Errorf("CreateVariants() variant %d frames = %d, want 3", i, len(g.Image))
+		}
+		if size := image.Pt(g.Config.Width, g.Config.Height); size != wantSize {
+			t.Errorf("CreateVariants() variant %d size = %v, want %v", i, size, wantSize)
+		}
+	}
+}
+
+func Test_medianCut(t *testing.T) {
+	var pixels []color.NRGBA
+	for i := range 100 {
+		pixels = append(pixels, color.NRGBA{R: uint8(i), A: 255}, color.NRGBA{B: 200, G: uint8(i), A: 255})
+	}
+
+	tests := []struct {
+		name  string
+		count int
+		want  int
+	}{
+		{name: "Single box", count: 1, want: 1},
+		{name: "Several boxes", count: 8, want: 8},
+		{name: "More boxes than colors", count: 1000, want: 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boxes := medianCut(pixels, tt.count)
+			if len(boxes) != tt.want {
+				t.Errorf("medianCut() boxes = %d, want %d", len(boxes), tt.want)
+			}
+
+			total := 0
+			for _, box := range boxes {
+				total += len(box)
+			}
+			if total != len(pixels) {
+				t.Errorf("medianCut() pixels = %d, want %d", total, len(pixels))
+			}
+		})
+	}
+}
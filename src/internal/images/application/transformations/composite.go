@@ -0,0 +1,210 @@
+package transformations
+
+import (
+	"fmt"
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"math"
+)
+
+func compose(sources []image.Image, composition domain.Composition) (image.Image, error) {
+	background := color.NRGBA{}
+	if composition.Background != "" {
+		c, err := parseHexColor(composition.Background)
+		if err != nil {
+			return nil, fmt.Errorf("background must be in the #RRGGBB or #RRGGBBAA format")
+		}
+		background = c
+	}
+
+	switch composition.Layout {
+	case domain.CompositionLayoutGrid:
+		return composeGrid(sources, composition, background), nil
+	case domain.CompositionLayoutHorizontal:
+		return composeHorizontal(sources, composition, background), nil
+	case domain.CompositionLayoutVertical:
+		return composeVertical(sources, composition, background), nil
+	case domain.CompositionLayoutLayers:
+		return composeLayers(sources, composition, background), nil
+	default:
+		return nil, fmt.Errorf("layout '%s' is not supported", composition.Layout)
+	}
+}
+
+// composeGrid fits every image into an equally sized cell, centering it within the cell. Unless given, the cell size is
+// the size of the largest image and the number of columns makes the grid as square as possible.
+func composeGrid(sources []image.Image, composition domain.Composition, background color.NRGBA) image.Image {
+	columns := composition.Columns
+	if columns == 0 {
+		columns = int(math.Ceil(math.Sqrt(float64(len(sources)))))
+	}
+	columns = min(columns, len(sources))
+	rows := (len(sources) + columns - 1) / columns
+
+	cellWidth, cellHeight := composition.Width, composition.Height
+	for _, src := range sources {
+		if composition.Width == 0 {
+			cellWidth = max(cellWidth, src.Bounds().Dx())
+		}
+		if composition.Height == 0 {
+			cellHeight = max(cellHeight, src.Bounds().Dy())
+		}
+	}
+
+	spacing := composition.Spacing
+	canvas := imaging.New(
+		columns*cellWidth+(columns+1)*spacing,
+		rows*cellHeight+(rows+1)*spacing,
+		background,
+	)
+
+	for i, src := range sources {
+		cell := image.Rect(0, 0, cellWidth, cellHeight).Add(image.Pt(
+			spacing+(i%columns)*(cellWidth+spacing),
+			spacing+(i/columns)*(cellHeight+spacing),
+		))
+		fitted := imaging.Fit(src, cellWidth, cellHeight, imaging.Lanczos)
+		canvas = imaging.Overlay(canvas, fitted, anchorPoint(cell, fitted.Bounds().Size(), imaging.Center), 1)
+	}
+
+	return canvas
+}
+
+// composeHorizontal scales all images to a common height and places them side by side. Unless given, the height is the
+// height of the smallest image, so that no image has to be enlarged.
+func composeHorizontal(sources []image.Image, composition domain.Composition, background color.NRGBA) image.Image {
+	height := composition.Height
+	if height == 0 {
+		height = math.MaxInt
+		for _, src := range sources {
+			height = min(height, src.Bounds().Dy())
+		}
+	}
+
+	scaled := make([]image.Image, len(sources))
+	width := composition.Spacing
+	for i, src := range sources {
+		scaled[i] = imaging.Resize(src, 0, height, imaging.Lanczos)
+		width += scaled[i].Bounds().Dx() + composition.Spacing
+	}
+
+	canvas := imaging.New(width, height+2*composition.Spacing, background)
+	x := composition.Spacing
+	for _, src := range scaled {
+		canvas = imaging.Overlay(canvas, src, image.Pt(x, composition.Spacing), 1)
+		x += src.Bounds().Dx() + composition.Spacing
+	}
+
+	return canvas
+}
+
+// composeVertical scales all images to a common width and stacks them on top of each other. Unless given, the width is
+// the width of the smallest image, so that no image has to be enlarged.
+func composeVertical(sources []image.Image, composition domain.Composition, background color.NRGBA) image.Image {
+	width := composition.Width
+	if width == 0 {
+		width = math.MaxInt
+		for _, src := range sources {
+			width = min(width, src.Bounds().Dx())
+		}
+	}
+
+	scaled := make([]image.Image, len(sources))
+	height := composition.Spacing
+	for i, src := range sources {
+		scaled[i] = imaging.Resize(src, width, 0, imaging.Lanczos)
+		height += scaled[i].Bounds().Dy() + composition.Spacing
+	}
+
+	canvas := imaging.New(width+2*composition.Spacing, height, background)
+	y := composition.Spacing
+	for _, src := range scaled {
+		canvas = imaging.Overlay(canvas, src, image.Pt(composition.Spacing, y), 1)
+		y += src.Bounds().Dy() + composition.Spacing
+	}
+
+	return canvas
+}
+
+// composeLayers draws the images on top of each other in order, each at its own position and with its own opacity and
+// blend mode. Unless given, the canvas has the size of the first image.
+func composeLayers(sources []image.Image, composition domain.Composition, background color.NRGBA) image.Image {
+	width, height := composition.Width, composition.Height
+	if width == 0 {
+		width = sources[0].Bounds().Dx()
+	}
+	if height == 0 {
+		height = sources[0].Bounds().Dy()
+	}
+
+	canvas := imaging.New(width, height, background)
+	for i, src := range sources {
+		layer := composition.Layers[i]
+		blendLayer(canvas, toNRGBA(src), image.Pt(layer.X, layer.Y), layer.Opacity, layer.BlendMode)
+	}
+
+	return canvas
+}
+
+// blendLayer draws the layer onto the canvas in place. The parts of the layer outside of the canvas are ignored.
+func blendLayer(canvas, layer *image.NRGBA, position image.Point, opacity float64, mode domain.BlendMode) {
+	area := layer.Bounds().Add(position).Intersect(canvas.Bounds())
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			base := canvas.NRGBAAt(x, y)
+			top := layer.NRGBAAt(x-position.X, y-position.Y)
+			canvas.SetNRGBA(x, y, blendPixel(base, top, opacity, mode))
+		}
+	}
+}
+
+// blendPixel combines the colors according to the blend mode and then composites the result over the base color
+// using the alpha of the top color multiplied by the opacity.
+func blendPixel(base, top color.NRGBA, opacity float64, mode domain.BlendMode) color.NRGBA {
+	topAlpha := float64(top.A) / 255 * opacity
+	baseAlpha := float64(base.A) / 255
+	outAlpha := topAlpha + baseAlpha*(1-topAlpha)
+	if outAlpha == 0 {
+		return color.NRGBA{}
+	}
+
+	channel := func(b, t uint8) uint8 {
+		bf, tf := float64(b)/255, float64(t)/255
+
+		// Where the base is transparent, there is nothing to blend with, so the top color is used as it is.
+		blended := (1-baseAlpha)*tf + baseAlpha*blendChannel(bf, tf, mode)
+
+		return clamp((blended*topAlpha + bf*baseAlpha*(1-topAlpha)) / outAlpha * 255)
+	}
+
+	return color.NRGBA{
+		R: channel(base.R, top.R),
+		G: channel(base.G, top.G),
+		B: channel(base.B, top.B),
+		A: clamp(outAlpha * 255),
+	}
+}
+
+func blendChannel(b, t float64, mode domain.BlendMode) float64 {
+	switch mode {
+	case domain.BlendModeMultiply:
+		return b * t
+	case domain.BlendModeScreen:
+		return 1 - (1-b)*(1-t)
+	case domain.BlendModeOverlay:
+		if b < 0.5 {
+			return 2 * b * t
+		}
+		return 1 - 2*(1-b)*(1-t)
+	case domain.BlendModeDarken:
+		return math.Min(b, t)
+	case domain.BlendModeLighten:
+		return math.Max(b, t)
+	case domain.BlendModeDifference:
+		return math.Abs(b - t)
+	default:
+		return t
+	}
+}
@@ -0,0 +1,133 @@
+package transformations
+
+import (
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"testing"
+)
+
+func Test_compose(t *testing.T) {
+	sources := []image.Image{
+		image.NewNRGBA(image.Rect(0, 0, 10, 10)),
+		image.NewNRGBA(image.Rect(0, 0, 20, 10)),
+		image.NewNRGBA(image.Rect(0, 0, 10, 20)),
+	}
+	layers := make([]domain.CompositionLayer, len(sources))
+	for i := range layers {
+		layers[i] = domain.CompositionLayer{Opacity: 1}
+	}
+
+	tests := []struct {
+		name        string
+		composition domain.Composition
+		want        image.Point
+		wantErr     bool
+	}{
+		{
+			name:        "Grid",
+			composition: domain.Composition{Layout: domain.CompositionLayoutGrid, Layers: layers},
+			want:        image.Pt(40, 40),
+			wantErr:     false,
+		},
+		{
+			name: "Grid with spacing and columns",
+			composition: domain.Composition{
+				Layout:  domain.CompositionLayoutGrid,
+				Columns: 3,
+				Spacing: 2,
+				Width:   10,
+				Height:  10,
+				Layers:  layers,
+			},
+			want:    image.Pt(38, 14),
+			wantErr: false,
+		},
+		{
+			name:        "Horizontal",
+			composition: domain.Composition{Layout: domain.CompositionLayoutHorizontal, Layers: layers},
+			want:        image.Pt(35, 10),
+			wantErr:     false,
+		},
+		{
+			name:        "Vertical",
+			composition: domain.Composition{Layout: domain.CompositionLayoutVertical, Spacing: 1, Layers: layers},
+			want:        image.Pt(12, 39),
+			wantErr:     false,
+		},
+		{
+			name:        "Layers",
+			composition: domain.Composition{Layout: domain.CompositionLayoutLayers, Layers: layers},
+			want:        image.Pt(10, 10),
+			wantErr:     false,
+		},
+		{
+			name: "Invalid background",
+			composition: domain.Composition{
+				Layout:     domain.CompositionLayoutGrid,
+				Background: "white",
+				Layers:     layers,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compose(sources, tt.composition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("compose() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != tt.want {
+				t.Errorf("compose() got size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_blendPixel(t *testing.T) {
+	type args struct {
+		base    color.NRGBA
+		top     color.NRGBA
+		opacity float64
+		mode    domain.BlendMode
+	}
+	tests := []struct {
+		name string
+		args args
+		want color.NRGBA
+	}{
+		{
+			name: "Normal",
+			args: args{base: color.NRGBA{R: 255, A: 255}, top: color.NRGBA{B: 255, A: 255}, opacity: 1},
+			want: color.NRGBA{B: 255, A: 255},
+		},
+		{
+			name: "Half opacity",
+			args: args{base: color.NRGBA{R: 255, A: 255}, top: color.NRGBA{B: 255, A: 255}, opacity: 0.5},
+			want: color.NRGBA{R: 128, B: 128, A: 255},
+		},
+		{
+			name: "Multiply",
+			args: args{
+				base:    color.NRGBA{R: 255, G: 128, A: 255},
+				top:     color.NRGBA{R: 128, G: 128, A: 255},
+				opacity: 1,
+				mode:    domain.BlendModeMultiply,
+			},
+			want: color.NRGBA{R: 128, G: 64, A: 255},
+		},
+		{
+			name: "Over transparent base",
+			args: args{base: color.NRGBA{}, top: color.NRGBA{G: 200, A: 255}, opacity: 1, mode: domain.BlendModeDarken},
+			want: color.NRGBA{G: 200, A: 255},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blendPixel(tt.args.base, tt.args.top, tt.args.opacity, tt.args.mode); colorDistance(got, tt.want) > 1 {
+				t.Errorf("blendPixel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package transformations
+
+import (
+	"fmt"
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"math"
+	"runtime"
+	"sync"
+)
+
+const maxFilterRadius = 5
+
+// maxFilterCost limits the number of pixels times the radius of the filters that look at the neighbourhood of every
+// pixel, which is what their work grows with.
+const maxFilterCost = domain.MaxImageDimension * domain.MaxImageDimension
+
+var (
+	sobelHorizontalKernel = [9]float64{-1, 0, 1, -2, 0, 2, -1, 0, 1}
+	sobelVerticalKernel   = [9]float64{-1, -2, -1, 0, 0, 0, 1, 2, 1}
+	laplacianKernel       = [9]float64{0, 1, 0, 1, -4, 1, 0, 1, 0}
+	embossKernel          = [9]float64{-2, -1, 0, -1, 1, 1, 0, 1, 2}
+)
+
+func convolve(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	values map[domain.TransformationValuesType][]float64,
+) (image.Image, error) {
+	kernel, err := parseValues(values, domain.Kernel)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(kernel) != 9 && len(kernel) != 25 {
+		return nil, fmt.Errorf("convolve values 'kernel' must contain 9 (3x3) or 25 (5x5) numbers")
+	}
+
+	// The divisor defaults to the sum of the kernel, which keeps the overall brightness of the image unchanged.
+	sum := 0.0
+	for _, v := range kernel {
+		sum += v
+	}
+	if sum == 0 {
+		sum = 1
+	}
+
+	divisor := optionOrDefault(options, domain.Divisor, sum)
+	if divisor == 0 {
+		return nil, fmt.Errorf("convolve option 'divisor' must not be zero")
+	}
+
+	for i := range kernel {
+		kernel[i] /= divisor
+	}
+
+	convolveOptions := &imaging.ConvolveOptions{Bias: int(options[domain.Bias])}
+	if len(kernel) == 9 {
+		return imaging.Convolve3x3(img, [9]float64(kernel), convolveOptions), nil
+	}
+
+	return imaging.Convolve5x5(img, [25]float64(kernel), convolveOptions), nil
+}
+
+func edgeDetect(img image.Image, parameters map[domain.TransformationParameterType]string) (image.Image, error) {
+	gray := imaging.Grayscale(img)
+
+	switch mode := domain.EdgeDetectionMode(parameters[domain.Mode]); mode {
+	case "", domain.EdgeDetectionModeSobel:
+		horizontal := imaging.Convolve3x3(gray, sobelHorizontalKernel, &imaging.ConvolveOptions{Abs: true})
+		vertical := imaging.Convolve3x3(gray, sobelVerticalKernel, &imaging.ConvolveOptions{Abs: true})
+
+		// The gradient magnitude is combined from both directions, written into the horizontal result in place.
+		for i := 0; i+3 < len(horizontal.Pix); i += 4 {
+			magnitude := clamp(math.Hypot(float64(horizontal.Pix[i]), float64(vertical.Pix[i])))
+			horizontal.Pix[i], horizontal.Pix[i+1], horizontal.Pix[i+2] = magnitude, magnitude, magnitude
+		}
+
+		return horizontal, nil
+	case domain.EdgeDetectionModeLaplacian:
+		return imaging.Convolve3x3(gray, laplacianKernel, &imaging.ConvolveOptions{Abs: true}), nil
+	default:
+		return nil, fmt.Errorf("edge_detect mode '%s' is not supported", mode)
+	}
+}
+
+func emboss(img image.Image) image.Image {
+	return imaging.Convolve3x3(img, embossKernel, nil)
+}
+
+func median(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	radius := int(optionOrDefault(options, domain.Radius, 1))
+	if radius < 1 || radius > maxFilterRadius {
+		return nil, fmt.Errorf("median option 'radius' must be between 1 and %d", maxFilterRadius)
+	}
+
+	src := toNRGBA(img)
+	if err := validateFilterCost("median", src.Bounds(), radius); err != nil {
+		return nil, err
+	}
+
+	dst := image.NewNRGBA(src.Bounds())
+	parallel(0, src.Bounds().Dy(), func(ys <-chan int) {
+		for y := range ys {
+			medianRow(src, dst, y, radius)
+		}
+	})
+
+	return dst, nil
+}
+
+// medianRow filters a row with a histogram of the window that slides along it, so that moving to the next pixel only
+// takes a column out and adds one. The median is tracked together with the number of values below it, which only
+// changes by a few steps from one pixel to the next.
+func medianRow(src, dst *image.NRGBA, y, radius int) {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	top, bottom := max(y-radius, 0), min(y+radius, height-1)
+
+	var histograms [3][256]int
+	var medians, below [3]int
+	count := 0
+
+	updateColumn := func(x, delta int) {
+		for wy := top; wy <= bottom; wy++ {
+			i := src.PixOffset(x, wy)
+			for c := range histograms {
+				v := int(src.Pix[i+c])
+				histograms[c][v] += delta
+				if v < medians[c] {
+					below[c] += delta
+				}
+			}
+		}
+		count += delta * (bottom - top + 1)
+	}
+
+	for x := 0; x <= min(radius, width-1); x++ {
+		updateColumn(x, 1)
+	}
+
+	for x := 0; x < width; x++ {
+		i := dst.PixOffset(x, y)
+		for c := range histograms {
+			for below[c] > count/2 {
+				medians[c]--
+				below[c] -= histograms[c][medians[c]]
+			}
+			for below[c]+histograms[c][medians[c]] <= count/2 {
+				below[c] += histograms[c][medians[c]]
+				medians[c]++
+			}
+			dst.Pix[i+c] = uint8(medians[c])
+		}
+		dst.Pix[i+3] = src.Pix[src.PixOffset(x, y)+3]
+
+		if x-radius >= 0 {
+			updateColumn(x-radius, -1)
+		}
+		if x+radius+1 < width {
+			updateColumn(x+radius+1, 1)
+		}
+	}
+}
+
+// validateFilterCost rejects images that are too large to be filtered with the given radius.
+func validateFilterCost(transformation string, bounds image.Rectangle, radius int) error {
+	if bounds.Dx()*bounds.Dy()*radius > maxFilterCost {
+		return fmt.Errorf("%s with radius %d is limited to images of %d pixels", transformation, radius, maxFilterCost/radius)
+	}
+
+	return nil
+}
+
+// parallel hands the indices from start to stop out to one goroutine per CPU, the way imaging processes the rows of an
+// image.
+func parallel(start, stop int, fn func(<-chan int)) {
+	count := stop - start
+	if count < 1 {
+		return
+	}
+
+	indices := make(chan int, count)
+	for i := start; i < stop; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), count) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(indices)
+		}()
+	}
+	wg.Wait()
+}
+
+func unsharpMask(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	sigma, ok := options[domain.Sigma]
+	if !ok || sigma <= 0 {
+		return nil, fmt.Errorf("unsharp_mask option 'sigma' is required and must be a positive number")
+	}
+
+	amount := optionOrDefault(options, domain.Amount, 1)
+	if amount < 0 {
+		return nil, fmt.Errorf("unsharp_mask option 'amount' must not be negative")
+	}
+
+	level := optionOrDefault(options, domain.Level, 0)
+	if level < 0 || level > 255 {
+		return nil, fmt.Errorf("unsharp_mask option 'level' must be between 0 and 255")
+	}
+
+	src := toNRGBA(img)
+	blurred := imaging.Blur(src, sigma)
+	dst := image.NewNRGBA(src.Bounds())
+
+	// Only differences above the threshold level are amplified, which keeps smooth areas such as skin or sky free of
+	// sharpened noise.
+	for i := 0; i+3 < len(src.Pix); i += 4 {
+		for c := 0; c < 3; c++ {
+			diff := float64(src.Pix[i+c]) - float64(blurred.Pix[i+c])
+			if math.Abs(diff) < level {
+				dst.Pix[i+c] = src.Pix[i+c]
+				continue
+			}
+			dst.Pix[i+c] = clamp(float64(src.Pix[i+c]) + amount*diff)
+		}
+		dst.Pix[i+3] = src.Pix[i+3]
+	}
+
+	return dst, nil
+}
@@ -0,0 +1,150 @@
+package transformations
+
+import (
+	"github.com/disintegration/imaging"
+	"image"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"math"
+)
+
+const (
+	ssimWindowSize = 8
+	ssimWindowStep = 4
+	// diffFade is how much of the first image shows through the unchanged pixels of a diff image.
+	diffFade = 0.25
+)
+
+var (
+	diffChangedColor = color.NRGBA{R: 255, A: 255}
+	ssimC1           = math.Pow(0.01*255, 2)
+	ssimC2           = math.Pow(0.03*255, 2)
+)
+
+// Diff compares two images and renders a diff image, which shows the changed pixels in red over a faded grayscale copy
+// of the first image. Images of different sizes can only be compared if resizing is allowed, in which case the second
+// image is resized to the size of the first one.
+func (s *Service) Diff(firstBytes, secondBytes []byte, resize bool, threshold int) ([]byte, *domain.DiffMetrics, error) {
+	sources, err := deserializeAll([][]byte{firstBytes, secondBytes})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	first, second := toNRGBA(sources[0]), toNRGBA(sources[1])
+	if first.Bounds().Size() != second.Bounds().Size() {
+		if !resize {
+			return nil, nil, commonerrors.NewInvalidInput("images differ in size, allow resizing to compare them")
+		}
+		second = imaging.Resize(second, first.Bounds().Dx(), first.Bounds().Dy(), imaging.Lanczos)
+	}
+
+	var metrics *domain.DiffMetrics
+	diffBytes, err := s.render("diff", func() (image.Image, error) {
+		var img image.Image
+		img, metrics = diff(first, second, uint8(threshold))
+		return img, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diffBytes, metrics, nil
+}
+
+// diff compares two images of the same size. A pixel is changed if any of its channels differs by more than the
+// threshold.
+func diff(first, second *image.NRGBA, threshold uint8) (*image.NRGBA, *domain.DiffMetrics) {
+	width, height := first.Bounds().Dx(), first.Bounds().Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	metrics := &domain.DiffMetrics{Width: width, Height: height}
+
+	// The luminance is kept in single precision, as it is plenty for the metrics and halves the memory used.
+	firstLuminance := make([]float32, width*height)
+	secondLuminance := make([]float32, width*height)
+	var squaredError float64
+	changed := 0
+	for y := range height {
+		for x := range width {
+			a, b := first.NRGBAAt(x, y), second.NRGBAAt(x, y)
+			for _, d := range []float64{
+				float64(a.R) - float64(b.R),
+				float64(a.G) - float64(b.G),
+				float64(a.B) - float64(b.B),
+			} {
+				squaredError += d * d
+			}
+
+			firstLuminance[y*width+x] = float32(luminance(a))
+			secondLuminance[y*width+x] = float32(luminance(b))
+
+			if colorDistance(a, b) > threshold {
+				changed++
+				dst.SetNRGBA(x, y, diffChangedColor)
+				continue
+			}
+			gray := uint8(255 - diffFade*(255-luminance(a)))
+			dst.SetNRGBA(x, y, color.NRGBA{R: gray, G: gray, B: gray, A: 255})
+		}
+	}
+
+	if width*height == 0 {
+		return dst, metrics
+	}
+
+	if meanSquaredError := squaredError / float64(3*width*height); meanSquaredError > 0 {
+		psnr := 10 * math.Log10(255*255/meanSquaredError)
+		metrics.PSNR = &psnr
+	}
+	metrics.SSIM = ssim(firstLuminance, secondLuminance, width, height)
+	metrics.ChangedPercentage = 100 * float64(changed) / float64(width*height)
+
+	return dst, metrics
+}
+
+// ssim computes the mean structural similarity of two luminance maps over overlapping windows. Images smaller than a
+// window are compared as a whole.
+func ssim(first, second []float32, width, height int) float64 {
+	windowWidth, windowHeight := min(width, ssimWindowSize), min(height, ssimWindowSize)
+
+	var sum float64
+	windows := 0
+	for y := 0; y+windowHeight <= height; y += ssimWindowStep {
+		for x := 0; x+windowWidth <= width; x += ssimWindowStep {
+			sum += ssimWindow(first, second, width, image.Rect(x, y, x+windowWidth, y+windowHeight))
+			windows++
+		}
+	}
+
+	return sum / float64(windows)
+}
+
+func ssimWindow(first, second []float32, width int, window image.Rectangle) float64 {
+	count := float64(window.Dx() * window.Dy())
+
+	var meanA, meanB float64
+	for y := window.Min.Y; y < window.Max.Y; y++ {
+		for x := window.Min.X; x < window.Max.X; x++ {
+			meanA += float64(first[y*width+x])
+			meanB += float64(second[y*width+x])
+		}
+	}
+	meanA /= count
+	meanB /= count
+
+	var varianceA, varianceB, covariance float64
+	for y := window.Min.Y; y < window.Max.Y; y++ {
+		for x := window.Min.X; x < window.Max.X; x++ {
+			a, b := float64(first[y*width+x])-meanA, float64(second[y*width+x])-meanB
+			varianceA += a * a
+			varianceB += b * b
+			covariance += a * b
+		}
+	}
+	varianceA /= count
+	varianceB /= count
+	covariance /= count
+
+	return (2*meanA*meanB + ssimC1) * (2*covariance + ssimC2) /
+		((meanA*meanA + meanB*meanB + ssimC1) * (varianceA + varianceB + ssimC2))
+}
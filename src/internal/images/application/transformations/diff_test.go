@@ -0,0 +1,79 @@
+package transformations
+
+import (
+	"github.com/disintegration/imaging"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+)
+
+func Test_diff(t *testing.T) {
+	base := imaging.New(20, 20, color.NRGBA{R: 100, G: 150, B: 200, A: 255})
+
+	quarterChanged := imaging.Clone(base)
+	draw.Draw(quarterChanged, image.Rect(0, 0, 10, 10), image.NewUniform(color.NRGBA{A: 255}), image.Point{}, draw.Src)
+
+	slightlyChanged := imaging.New(20, 20, color.NRGBA{R: 102, G: 150, B: 200, A: 255})
+
+	tests := []struct {
+		name        string
+		second      *image.NRGBA
+		threshold   uint8
+		wantChanged float64
+		wantPSNR    bool
+		wantSSIM    float64
+	}{
+		{
+			name:        "Identical",
+			second:      base,
+			wantChanged: 0,
+			wantPSNR:    false,
+			wantSSIM:    1,
+		},
+		{
+			name:        "Quarter changed",
+			second:      quarterChanged,
+			wantChanged: 25,
+			wantPSNR:    true,
+			wantSSIM:    0.44,
+		},
+		{
+			name:        "Change below threshold",
+			second:      slightlyChanged,
+			threshold:   2,
+			wantChanged: 0,
+			wantPSNR:    true,
+			wantSSIM:    1,
+		},
+		{
+			name:        "Change above threshold",
+			second:      slightlyChanged,
+			threshold:   1,
+			wantChanged: 100,
+			wantPSNR:    true,
+			wantSSIM:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, got := diff(base, tt.second, tt.threshold)
+			if img.Bounds() != base.Bounds() {
+				t.Errorf("diff() image bounds = %v, want %v", img.Bounds(), base.Bounds())
+			}
+			if got.ChangedPercentage != tt.wantChanged {
+				t.Errorf("diff() changed = %v, want %v", got.ChangedPercentage, tt.wantChanged)
+			}
+			if (got.PSNR != nil) != tt.wantPSNR {
+				t.Errorf("diff() PSNR = %v, want finite %v", got.PSNR, tt.wantPSNR)
+			}
+			if math.Abs(got.SSIM-tt.wantSSIM) > 0.05 {
+				t.Errorf("diff() SSIM = %v, want %v", got.SSIM, tt.wantSSIM)
+			}
+			if tt.wantChanged > 0 && img.NRGBAAt(0, 0) != diffChangedColor {
+				t.Errorf("diff() changed pixel = %v, want %v", img.NRGBAAt(0, 0), diffChangedColor)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package transformations
+
+import (
+	"fmt"
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"maps"
+	"math"
+	"math/rand/v2"
+)
+
+func vignette(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	radius := optionOrDefault(options, domain.Radius, 0.5)
+	if radius < 0 || radius >= 1 {
+		return nil, fmt.Errorf("vignette option 'radius' must be at least 0 and less than 1")
+	}
+
+	strength := optionOrDefault(options, domain.Strength, 0.5)
+	if strength < 0 || strength > 1 {
+		return nil, fmt.Errorf("vignette option 'strength' must be between 0 and 1")
+	}
+
+	dst := imaging.Clone(img)
+	width, height := dst.Bounds().Dx(), dst.Bounds().Dy()
+	centerX, centerY := float64(width)/2, float64(height)/2
+	maxDistance := math.Hypot(centerX, centerY)
+
+	// The radius is the fraction of the distance from the center to the corners at which the darkening begins, and it
+	// then grows smoothly towards the corners.
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			distance := math.Hypot(float64(x)+0.5-centerX, float64(y)+0.5-centerY) / maxDistance
+			t := math.Max(0, math.Min(1, (distance-radius)/(1-radius)))
+			factor := 1 - strength*t*t*(3-2*t)
+
+			i := dst.PixOffset(x, y)
+			for c := 0; c < 3; c++ {
+				dst.Pix[i+c] = clamp(float64(dst.Pix[i+c]) * factor)
+			}
+		}
+	}
+
+	return dst, nil
+}
+
+func pixelate(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	size, ok := options[domain.Size]
+	if !ok || int(size) < 1 {
+		return nil, fmt.Errorf("pixelate option 'size' is required and must be a positive number")
+	}
+
+	return pixelateImage(img, int(size)), nil
+}
+
+func noise(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	amount := optionOrDefault(options, domain.Amount, 20)
+	if amount < 0 || amount > 255 {
+		return nil, fmt.Errorf("noise option 'amount' must be between 0 and 255")
+	}
+
+	mode := domain.NoiseMode(parameters[domain.Mode])
+	if mode != "" && mode != domain.NoiseModeMonochrome && mode != domain.NoiseModeColor {
+		return nil, fmt.Errorf("noise mode '%s' is not supported", mode)
+	}
+
+	// The seed makes the grain reproducible, which is why a seeded pseudo-random source is used on purpose.
+	seed := uint64(optionOrDefault(options, domain.Seed, 0))
+	random := rand.New(rand.NewPCG(seed, seed)) // #nosec G404 -- film grain does not need to be unpredictable
+
+	dst := imaging.Clone(img)
+	for i := 0; i+3 < len(dst.Pix); i += 4 {
+		grain := random.NormFloat64() * amount
+		for c := 0; c < 3; c++ {
+			if mode == domain.NoiseModeColor && c > 0 {
+				grain = random.NormFloat64() * amount
+			}
+			dst.Pix[i+c] = clamp(float64(dst.Pix[i+c]) + grain)
+		}
+	}
+
+	return dst, nil
+}
+
+func popArt(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	levels := int(optionOrDefault(options, domain.Levels, 4))
+	if levels < 2 || levels > 256 {
+		return nil, fmt.Errorf("pop_art option 'levels' must be between 2 and 256")
+	}
+
+	saturation := optionOrDefault(options, domain.Factor, 50)
+
+	return applyLUT(imaging.AdjustSaturation(img, saturation), domain.ChannelAll, posterizeLUT(levels)), nil
+}
+
+func oilPaint(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	radius := int(optionOrDefault(options, domain.Radius, 3))
+	if radius < 1 || radius > maxFilterRadius {
+		return nil, fmt.Errorf("oil_paint option 'radius' must be between 1 and %d", maxFilterRadius)
+	}
+
+	levels := int(optionOrDefault(options, domain.Levels, 20))
+	if levels < 2 || levels > 256 {
+		return nil, fmt.Errorf("oil_paint option 'levels' must be between 2 and 256")
+	}
+
+	src := toNRGBA(img)
+	if err := validateFilterCost("oil_paint", src.Bounds(), radius); err != nil {
+		return nil, err
+	}
+
+	dst := image.NewNRGBA(src.Bounds())
+	parallel(0, src.Bounds().Dy(), func(ys <-chan int) {
+		for y := range ys {
+			oilPaintRow(src, dst, y, radius, levels)
+		}
+	})
+
+	return dst, nil
+}
+
+// oilPaintRow gives every pixel of a row the average color of the most common intensity level in its neighbourhood,
+// which flattens details into brush-like patches. Like the median, the levels are counted in a window that slides
+// along the row.
+func oilPaintRow(src, dst *image.NRGBA, y, radius, levels int) {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	top, bottom := max(y-radius, 0), min(y+radius, height-1)
+
+	counts := make([]int, levels)
+	sums := make([][3]int, levels)
+
+	updateColumn := func(x, delta int) {
+		for wy := top; wy <= bottom; wy++ {
+			i := src.PixOffset(x, wy)
+			r, g, b := int(src.Pix[i]), int(src.Pix[i+1]), int(src.Pix[i+2])
+			level := (r + g + b) * (levels - 1) / (3 * 255)
+			counts[level] += delta
+			sums[level][0] += delta * r
+			sums[level][1] += delta * g
+			sums[level][2] += delta * b
+		}
+	}
+
+	for x := 0; x <= min(radius, width-1); x++ {
+		updateColumn(x, 1)
+	}
+
+	for x := 0; x < width; x++ {
+		best := 0
+		for level, count := range counts {
+			if count > counts[best] {
+				best = level
+			}
+		}
+
+		i := dst.PixOffset(x, y)
+		for c := 0; c < 3; c++ {
+			dst.Pix[i+c] = uint8(sums[best][c] / counts[best])
+		}
+		dst.Pix[i+3] = src.Pix[src.PixOffset(x, y)+3]
+
+		if x-radius >= 0 {
+			updateColumn(x-radius, -1)
+		}
+		if x+radius+1 < width {
+			updateColumn(x+radius+1, 1)
+		}
+	}
+}
+
+// withoutFeather turns the feathering of remove_background_color off for animations. GIF pixels are either fully
+// transparent or fully opaque, so a feathered edge would be cut off at an arbitrary alpha, which is why asking for a
+// feather is rejected.
+func withoutFeather(t domain.Transformation) (domain.Transformation, error) {
+	if feather, ok := t.Options[domain.Feather]; ok && feather != 0 {
+		return t, fmt.Errorf("remove_background_color option 'feather' is not supported for animations")
+	}
+
+	options := maps.Clone(t.Options)
+	if options == nil {
+		options = make(map[domain.TransformationOptionType]float64)
+	}
+	options[domain.Feather] = 0
+	t.Options = options
+
+	return t, nil
+}
+
+func removeBackgroundColor(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	if _, ok := parameters[domain.Color]; !ok {
+		return nil, fmt.Errorf("remove_background_color parameter 'color' is required")
+	}
+
+	key, err := parseColor(parameters, domain.Color, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Distances are measured in the RGB space, where the largest possible one is between black and white.
+	maxDistance := math.Sqrt(3 * 255 * 255)
+
+	tolerance := optionOrDefault(options, domain.Tolerance, 40)
+	if tolerance < 0 || tolerance > maxDistance {
+		return nil, fmt.Errorf("remove_background_color option 'tolerance' must be between 0 and %.0f", maxDistance)
+	}
+
+	feather := optionOrDefault(options, domain.Feather, 20)
+	if feather < 0 {
+		return nil, fmt.Errorf("remove_background_color option 'feather' must not be negative")
+	}
+
+	// Pixels within the tolerance become fully transparent, and pixels within the feather distance beyond it fade in
+	// gradually, which avoids hard, jagged edges around the subject.
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		dr, dg, db := float64(c.R)-float64(key.R), float64(c.G)-float64(key.G), float64(c.B)-float64(key.B)
+		distance := math.Sqrt(dr*dr + dg*dg + db*db)
+
+		switch {
+		case distance <= tolerance:
+			c.A = 0
+		case distance < tolerance+feather:
+			c.A = clamp(float64(c.A) * (distance - tolerance) / feather)
+		}
+		return c
+	}), nil
+}
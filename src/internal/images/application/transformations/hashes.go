@@ -0,0 +1,122 @@
+package transformations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"math"
+	"slices"
+)
+
+const (
+	hashSize  = 8
+	pHashSize = 32
+)
+
+// CreateHashes computes the content hash of the image and its perceptual hashes. The perceptual hashes only look at a
+// tiny copy of the image, so they are computed from the preview, which is much cheaper to decode than the image itself.
+func (s *Service) CreateHashes(imageBytes, previewBytes []byte) (domain.ImageHashes, error) {
+	preview, _, err := deserialize(previewBytes)
+	if err != nil {
+		return domain.ImageHashes{}, err
+	}
+
+	contentHash := sha256.Sum256(imageBytes)
+
+	return domain.ImageHashes{
+		ContentHash: hex.EncodeToString(contentHash[:]),
+		AHash:       averageHash(preview),
+		DHash:       differenceHash(preview),
+		PHash:       perceptualHash(preview),
+	}, nil
+}
+
+// averageHash sets a bit for every pixel of an 8x8 grayscale copy of the image that is brighter than the average.
+func averageHash(img image.Image) domain.Hash {
+	pixels := grayscalePixels(img, hashSize, hashSize)
+
+	mean := 0.0
+	for _, v := range pixels {
+		mean += v
+	}
+	mean /= float64(len(pixels))
+
+	var hash domain.Hash
+	for i, v := range pixels {
+		if v > mean {
+			hash |= 1 << i
+		}
+	}
+
+	return hash
+}
+
+// differenceHash sets a bit for every pixel of a 9x8 grayscale copy of the image that is brighter than its right
+// neighbour, which makes it follow the gradients of the image rather than its overall brightness.
+func differenceHash(img image.Image) domain.Hash {
+	pixels := grayscalePixels(img, hashSize+1, hashSize)
+
+	var hash domain.Hash
+	for y := range hashSize {
+		for x := range hashSize {
+			if pixels[y*(hashSize+1)+x] > pixels[y*(hashSize+1)+x+1] {
+				hash |= 1 << (y*hashSize + x)
+			}
+		}
+	}
+
+	return hash
+}
+
+// perceptualHash takes the lowest frequencies of the discrete cosine transform of a 32x32 grayscale copy of the image
+// and sets a bit for every one above their median. The lowest frequencies describe the structure of the image, which
+// survives resizing, recompression and small color changes.
+func perceptualHash(img image.Image) domain.Hash {
+	pixels := grayscalePixels(img, pHashSize, pHashSize)
+
+	coefficients := make([]float64, 0, hashSize*hashSize)
+	for v := range hashSize {
+		for u := range hashSize {
+			sum := 0.0
+			for y := range pHashSize {
+				for x := range pHashSize {
+					sum += pixels[y*pHashSize+x] *
+						math.Cos(float64(2*x+1)*float64(u)*math.Pi/(2*pHashSize)) *
+						math.Cos(float64(2*y+1)*float64(v)*math.Pi/(2*pHashSize))
+				}
+			}
+			coefficients = append(coefficients, sum)
+		}
+	}
+
+	// The first coefficient is the average brightness, which would skew the median, so it is left out of it.
+	sorted := slices.Clone(coefficients[1:])
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash domain.Hash
+	for i, c := range coefficients {
+		if c > median {
+			hash |= 1 << i
+		}
+	}
+
+	return hash
+}
+
+// grayscalePixels scales the image to exactly the given size, ignoring its aspect ratio, and returns the luminance of
+// its pixels row by row.
+func grayscalePixels(img image.Image, width, height int) []float64 {
+	small := imaging.Resize(img, width, height, imaging.Box)
+
+	pixels := make([]float64, 0, width*height)
+	for y := range height {
+		for x := range width {
+			pixels = append(pixels, luminance(small.NRGBAAt(x, y)))
+		}
+	}
+
+	return pixels
+}
@@ -0,0 +1,74 @@
+package transformations
+
+import (
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"testing"
+)
+
+// generateBlockImage returns an image made of blocks of different colors, which is closer to a photo than a smooth
+// gradient, whose frequencies are too weak to be hashed reliably.
+func generateBlockImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			i, j := x*8/width, y*6/height
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8((i*37 + j*91) % 256),
+				G: uint8((i*73 + j*19) % 256),
+				B: uint8((i*11 + j*53) % 256),
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func Test_perceptualHashes(t *testing.T) {
+	original := generateBlockImage(200, 150)
+
+	hashes := []struct {
+		name string
+		hash func(image.Image) domain.Hash
+	}{
+		{name: "aHash", hash: averageHash},
+		{name: "dHash", hash: differenceHash},
+		{name: "pHash", hash: perceptualHash},
+	}
+
+	tests := []struct {
+		name        string
+		img         image.Image
+		maxDistance int
+		minDistance int
+	}{
+		{
+			name:        "Resized",
+			img:         imaging.Resize(original, 80, 60, imaging.Lanczos),
+			maxDistance: 6,
+		},
+		{
+			name:        "Slightly brighter",
+			img:         imaging.AdjustBrightness(original, 5),
+			maxDistance: 6,
+		},
+		{
+			name:        "Flipped",
+			img:         imaging.FlipH(imaging.FlipV(original)),
+			maxDistance: 64,
+			minDistance: 12,
+		},
+	}
+	for _, h := range hashes {
+		for _, tt := range tests {
+			t.Run(h.name+" "+tt.name, func(t *testing.T) {
+				distance := h.hash(original).Distance(h.hash(tt.img))
+				if distance > tt.maxDistance || distance < tt.minDistance {
+					t.Errorf("%s distance = %d, want between %d and %d", h.name, distance, tt.minDistance, tt.maxDistance)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package transformations
+
+import (
+	"cmp"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"slices"
+)
+
+const paletteIterations = 10
+
+// CreatePalette finds the dominant colors of the image. Like the hashes, the palette does not need more detail than the
+// preview has, so it is computed from the preview.
+func (s *Service) CreatePalette(previewBytes []byte) (domain.Palette, error) {
+	preview, _, err := deserialize(previewBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return createPalette(preview, domain.PaletteSize), nil
+}
+
+// createPalette groups the opaque pixels of the image into at most the given number of colors with k-means. The
+// clusters are seeded with the boxes found by median cut, which are already close to the result, so a few iterations
+// are enough. Transparent pixels are left out, as their color is not seen.
+func createPalette(img image.Image, size int) domain.Palette {
+	pixels, _ := samplePixels(img)
+	boxes := medianCut(pixels, size)
+	if len(boxes) == 0 {
+		return domain.Palette{}
+	}
+
+	centers := make([][3]float64, len(boxes))
+	for i, box := range boxes {
+		c := box.average()
+		centers[i] = [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+	}
+
+	counts := make([]int, len(centers))
+	for range paletteIterations {
+		sums := make([][3]float64, len(centers))
+		clear(counts)
+		for _, c := range pixels {
+			nearest := nearestCenter(centers, c)
+			sums[nearest][0] += float64(c.R)
+			sums[nearest][1] += float64(c.G)
+			sums[nearest][2] += float64(c.B)
+			counts[nearest]++
+		}
+
+		for i := range centers {
+			if counts[i] > 0 {
+				for j := range 3 {
+					centers[i][j] = sums[i][j] / float64(counts[i])
+				}
+			}
+		}
+	}
+
+	palette := make(domain.Palette, 0, len(centers))
+	for i, center := range centers {
+		if counts[i] == 0 {
+			continue
+		}
+
+		palette = append(palette, domain.PaletteColor{
+			R:          uint8(center[0] + 0.5),
+			G:          uint8(center[1] + 0.5),
+			B:          uint8(center[2] + 0.5),
+			Proportion: float64(counts[i]) / float64(len(pixels)),
+		})
+	}
+	slices.SortStableFunc(palette, func(a, b domain.PaletteColor) int {
+		return cmp.Compare(b.Proportion, a.Proportion)
+	})
+
+	return palette
+}
+
+func nearestCenter(centers [][3]float64, c color.NRGBA) int {
+	nearest, nearestDistance := 0, -1.0
+	for i, center := range centers {
+		dr := center[0] - float64(c.R)
+		dg := center[1] - float64(c.G)
+		db := center[2] - float64(c.B)
+		if distance := dr*dr + dg*dg + db*db; nearestDistance < 0 || distance < nearestDistance {
+			nearest, nearestDistance = i, distance
+		}
+	}
+
+	return nearest
+}
@@ -0,0 +1,61 @@
+package transformations
+
+import (
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+)
+
+func Test_createPalette(t *testing.T) {
+	// Three quarters red and one quarter blue.
+	redAndBlue := imaging.New(40, 40, color.NRGBA{R: 255, A: 255})
+	draw.Draw(redAndBlue, image.Rect(0, 30, 40, 40), image.NewUniform(color.NRGBA{B: 255, A: 255}), image.Point{}, draw.Src)
+
+	// Half white and half transparent.
+	halfTransparent := imaging.New(20, 20, color.NRGBA{})
+	draw.Draw(halfTransparent, image.Rect(0, 0, 10, 20), image.NewUniform(color.NRGBA{R: 255, G: 255, B: 255, A: 255}), image.Point{}, draw.Src)
+
+	tests := []struct {
+		name string
+		img  image.Image
+		want domain.Palette
+	}{
+		{
+			name: "Single color",
+			img:  imaging.New(30, 20, color.NRGBA{G: 128, A: 255}),
+			want: domain.Palette{{G: 128, Proportion: 1}},
+		},
+		{
+			name: "Two colors",
+			img:  redAndBlue,
+			want: domain.Palette{{R: 255, Proportion: 0.75}, {B: 255, Proportion: 0.25}},
+		},
+		{
+			name: "Transparent pixels are left out",
+			img:  halfTransparent,
+			want: domain.Palette{{R: 255, G: 255, B: 255, Proportion: 1}},
+		},
+		{
+			name: "Fully transparent",
+			img:  imaging.New(10, 10, color.NRGBA{}),
+			want: domain.Palette{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := createPalette(tt.img, domain.PaletteSize)
+			if len(got) != len(tt.want) {
+				t.Fatalf("createPalette() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Hex() != tt.want[i].Hex() || math.Abs(got[i].Proportion-tt.want[i].Proportion) > 0.01 {
+					t.Errorf("createPalette()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
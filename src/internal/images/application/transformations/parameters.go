@@ -0,0 +1,223 @@
+package transformations
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var anchors = map[domain.AnchorType]imaging.Anchor{
+	domain.AnchorCenter:      imaging.Center,
+	domain.AnchorTopLeft:     imaging.TopLeft,
+	domain.AnchorTop:         imaging.Top,
+	domain.AnchorTopRight:    imaging.TopRight,
+	domain.AnchorLeft:        imaging.Left,
+	domain.AnchorRight:       imaging.Right,
+	domain.AnchorBottomLeft:  imaging.BottomLeft,
+	domain.AnchorBottom:      imaging.Bottom,
+	domain.AnchorBottomRight: imaging.BottomRight,
+}
+
+var filters = map[domain.FilterType]imaging.ResampleFilter{
+	domain.FilterLanczos:         imaging.Lanczos,
+	domain.FilterCatmullRom:      imaging.CatmullRom,
+	domain.FilterBox:             imaging.Box,
+	domain.FilterNearestNeighbor: imaging.NearestNeighbor,
+}
+
+func parseAnchor(parameters map[domain.TransformationParameterType]string) (imaging.Anchor, error) {
+	value, ok := parameters[domain.Anchor]
+	if !ok {
+		return imaging.Center, nil
+	}
+
+	anchor, ok := anchors[domain.AnchorType(value)]
+	if !ok {
+		return imaging.Center, fmt.Errorf("anchor '%s' is not supported", value)
+	}
+
+	return anchor, nil
+}
+
+func parseFilter(parameters map[domain.TransformationParameterType]string) (imaging.ResampleFilter, error) {
+	value, ok := parameters[domain.Filter]
+	if !ok {
+		return imaging.Lanczos, nil
+	}
+
+	filter, ok := filters[domain.FilterType(value)]
+	if !ok {
+		return imaging.Lanczos, fmt.Errorf("filter '%s' is not supported", value)
+	}
+
+	return filter, nil
+}
+
+func validateImageDimensions(width, height int) error {
+	if width < 1 || height < 1 {
+		return fmt.Errorf("image size %dx%d must be at least 1x1", width, height)
+	}
+	if width > domain.MaxImageDimension || height > domain.MaxImageDimension {
+		return fmt.Errorf(
+			"image size %dx%d exceeds the maximum of %dx%d", width, height, domain.MaxImageDimension, domain.MaxImageDimension,
+		)
+	}
+
+	return nil
+}
+
+// parseColor reads a color in the #RRGGBB or #RRGGBBAA format. The fallback is returned if the parameter is not set.
+func parseColor(
+	parameters map[domain.TransformationParameterType]string,
+	parameter domain.TransformationParameterType,
+	fallback color.NRGBA,
+) (color.NRGBA, error) {
+	value, ok := parameters[parameter]
+	if !ok {
+		return fallback, nil
+	}
+
+	c, err := parseHexColor(value)
+	if err != nil {
+		return fallback, fmt.Errorf("%s '%s' must be in the #RRGGBB or #RRGGBBAA format", parameter, value)
+	}
+
+	return c, nil
+}
+
+func parseHexColor(value string) (color.NRGBA, error) {
+	bytes, err := hex.DecodeString(strings.TrimPrefix(value, "#"))
+	if err != nil || (len(bytes) != 3 && len(bytes) != 4) {
+		return color.NRGBA{}, fmt.Errorf("invalid color '%s'", value)
+	}
+
+	c := color.NRGBA{R: bytes[0], G: bytes[1], B: bytes[2], A: 255}
+	if len(bytes) == 4 {
+		c.A = bytes[3]
+	}
+
+	return c, nil
+}
+
+func parseChannel(parameters map[domain.TransformationParameterType]string) (domain.ChannelType, error) {
+	value, ok := parameters[domain.Channel]
+	if !ok {
+		return domain.ChannelAll, nil
+	}
+
+	switch channel := domain.ChannelType(value); channel {
+	case domain.ChannelAll, domain.ChannelRed, domain.ChannelGreen, domain.ChannelBlue:
+		return channel, nil
+	default:
+		return domain.ChannelAll, fmt.Errorf("channel '%s' is not supported", value)
+	}
+}
+
+// parseValues returns a copy of a list of numbers, so that the transformations can modify it without affecting the
+// next image they are applied to.
+func parseValues(
+	values map[domain.TransformationValuesType][]float64,
+	name domain.TransformationValuesType,
+) ([]float64, error) {
+	numbers, ok := values[name]
+	if !ok || len(numbers) == 0 {
+		return nil, fmt.Errorf("values '%s' are required and must be a list of numbers", name)
+	}
+
+	return slices.Clone(numbers), nil
+}
+
+// parseRegions reads a semicolon-separated list of rectangles, each given as x,y,width,height.
+func parseRegions(parameters map[domain.TransformationParameterType]string) ([]image.Rectangle, error) {
+	value, ok := parameters[domain.Regions]
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("parameter '%s' is required", domain.Regions)
+	}
+
+	var regions []image.Rectangle
+	for _, field := range strings.Split(value, ";") {
+		numbers, err := splitNumbers(field)
+		if err != nil || len(numbers) != 4 {
+			return nil, fmt.Errorf("parameter '%s' must be a semicolon-separated list of x,y,width,height rectangles", domain.Regions)
+		}
+
+		x, y, width, height := int(numbers[0]), int(numbers[1]), int(numbers[2]), int(numbers[3])
+		regions = append(regions, image.Rect(x, y, x+width, y+height))
+	}
+
+	return regions, nil
+}
+
+func splitNumbers(value string) ([]float64, error) {
+	var numbers []float64
+	for _, field := range strings.Split(value, ",") {
+		number, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+
+	return numbers, nil
+}
+
+func optionOrDefault(
+	options map[domain.TransformationOptionType]float64,
+	option domain.TransformationOptionType,
+	fallback float64,
+) float64 {
+	value, ok := options[option]
+	if !ok {
+		return fallback
+	}
+
+	return value
+}
+
+// parseStrength reads the strength option shared by the automatic corrections, which defaults to the full effect.
+func parseStrength(
+	transformationType domain.TransformationType,
+	options map[domain.TransformationOptionType]float64,
+) (float64, error) {
+	strength := optionOrDefault(options, domain.Strength, 1)
+	if strength < 0 || strength > 1 {
+		return 0, fmt.Errorf("%s option 'strength' must be between 0 and 1", transformationType)
+	}
+
+	return strength, nil
+}
+
+// anchorPoint returns the top-left point at which an image of the given size has to be placed inside the bounds so
+// that it is aligned according to the anchor.
+func anchorPoint(bounds image.Rectangle, size image.Point, anchor imaging.Anchor) image.Point {
+	minX, minY := bounds.Min.X, bounds.Min.Y
+	maxX, maxY := bounds.Max.X-size.X, bounds.Max.Y-size.Y
+	midX, midY := minX+(maxX-minX)/2, minY+(maxY-minY)/2
+
+	switch anchor {
+	case imaging.TopLeft:
+		return image.Pt(minX, minY)
+	case imaging.Top:
+		return image.Pt(midX, minY)
+	case imaging.TopRight:
+		return image.Pt(maxX, minY)
+	case imaging.Left:
+		return image.Pt(minX, midY)
+	case imaging.Right:
+		return image.Pt(maxX, midY)
+	case imaging.BottomLeft:
+		return image.Pt(minX, maxY)
+	case imaging.Bottom:
+		return image.Pt(midX, maxY)
+	case imaging.BottomRight:
+		return image.Pt(maxX, maxY)
+	default:
+		return image.Pt(midX, midY)
+	}
+}
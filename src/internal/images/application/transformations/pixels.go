@@ -0,0 +1,280 @@
+package transformations
+
+import (
+	"github.com/disintegration/imaging"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"math"
+)
+
+// toNRGBA returns the image as NRGBA with its bounds starting at (0, 0), copying it only if necessary.
+func toNRGBA(img image.Image) *image.NRGBA {
+	if src, ok := img.(*image.NRGBA); ok && src.Bounds().Min == (image.Point{}) {
+		return src
+	}
+
+	return imaging.Clone(img)
+}
+
+// colorDistance returns the largest difference between the channels of two colors. Fully transparent colors are
+// considered equal regardless of their color channels.
+func colorDistance(a, b color.NRGBA) uint8 {
+	if a.A == 0 && b.A == 0 {
+		return 0
+	}
+
+	return max(absDiff(a.R, b.R), absDiff(a.G, b.G), absDiff(a.B, b.B), absDiff(a.A, b.A))
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// applyLUT maps the selected channels of every pixel through a lookup table. Alpha is never changed.
+func applyLUT(img image.Image, channel domain.ChannelType, lut [256]uint8) image.Image {
+	identity := identityLUT()
+	red, green, blue := identity, identity, identity
+	if channel == domain.ChannelAll || channel == domain.ChannelRed {
+		red = lut
+	}
+	if channel == domain.ChannelAll || channel == domain.ChannelGreen {
+		green = lut
+	}
+	if channel == domain.ChannelAll || channel == domain.ChannelBlue {
+		blue = lut
+	}
+
+	return applyChannelLUTs(img, red, green, blue)
+}
+
+// applyChannelLUTs maps every channel of every pixel through its own lookup table. Alpha is never changed.
+func applyChannelLUTs(img image.Image, red, green, blue [256]uint8) image.Image {
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		return color.NRGBA{R: red[c.R], G: green[c.G], B: blue[c.B], A: c.A}
+	})
+}
+
+func identityLUT() [256]uint8 {
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = uint8(i)
+	}
+	return lut
+}
+
+// blendLUT moves every entry of the lookup table towards the identity, with strength = 1 keeping the table as it is
+// and strength = 0 turning it into the identity.
+func blendLUT(lut [256]uint8, strength float64) [256]uint8 {
+	for i := range lut {
+		lut[i] = clamp(float64(i) + (float64(lut[i])-float64(i))*strength)
+	}
+	return lut
+}
+
+// histogram holds per-channel and luminance pixel counts of an image. Fully transparent pixels are not counted, since
+// their color channels carry no visible information.
+type histogram struct {
+	red       [256]int
+	green     [256]int
+	blue      [256]int
+	luminance [256]int
+	total     int
+}
+
+func computeHistogram(img image.Image) histogram {
+	src := toNRGBA(img)
+
+	var h histogram
+	for i := 0; i+3 < len(src.Pix); i += 4 {
+		c := color.NRGBA{R: src.Pix[i], G: src.Pix[i+1], B: src.Pix[i+2], A: src.Pix[i+3]}
+		if c.A == 0 {
+			continue
+		}
+		h.red[c.R]++
+		h.green[c.G]++
+		h.blue[c.B]++
+		h.luminance[clamp(luminance(c))]++
+		h.total++
+	}
+
+	return h
+}
+
+// percentile returns the smallest value below which the given fraction of the counted pixels lie.
+func percentile(counts [256]int, total int, fraction float64) int {
+	limit := int(math.Ceil(float64(total) * fraction))
+	sum := 0
+	for i, count := range counts {
+		sum += count
+		if sum >= limit && sum > 0 {
+			return i
+		}
+	}
+	return 255
+}
+
+// curveLUT builds a lookup table from control points using monotone cubic interpolation, so that the curve passes
+// through every point without overshooting between them. The points must be ordered by their x values.
+func curveLUT(xs, ys []float64) [256]uint8 {
+	n := len(xs)
+	slopes := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		slopes[i] = (ys[i+1] - ys[i]) / (xs[i+1] - xs[i])
+	}
+
+	tangents := make([]float64, n)
+	tangents[0], tangents[n-1] = slopes[0], slopes[n-2]
+	for i := 1; i < n-1; i++ {
+		if slopes[i-1]*slopes[i] <= 0 {
+			tangents[i] = 0
+		} else {
+			tangents[i] = (slopes[i-1] + slopes[i]) / 2
+		}
+	}
+
+	// Fritsch-Carlson adjustment of the tangents to preserve monotonicity.
+	for i := 0; i < n-1; i++ {
+		if slopes[i] == 0 {
+			tangents[i], tangents[i+1] = 0, 0
+			continue
+		}
+		a, b := tangents[i]/slopes[i], tangents[i+1]/slopes[i]
+		if h := math.Hypot(a, b); h > 3 {
+			tangents[i] = 3 * a / h * slopes[i]
+			tangents[i+1] = 3 * b / h * slopes[i]
+		}
+	}
+
+	var lut [256]uint8
+	segment := 0
+	for x := range lut {
+		fx := float64(x)
+		switch {
+		case fx <= xs[0]:
+			lut[x] = clamp(ys[0])
+			continue
+		case fx >= xs[n-1]:
+			lut[x] = clamp(ys[n-1])
+			continue
+		}
+
+		for fx > xs[segment+1] {
+			segment++
+		}
+
+		width := xs[segment+1] - xs[segment]
+		t := (fx - xs[segment]) / width
+		t2, t3 := t*t, t*t*t
+		lut[x] = clamp((2*t3-3*t2+1)*ys[segment] +
+			(t3-2*t2+t)*width*tangents[segment] +
+			(-2*t3+3*t2)*ys[segment+1] +
+			(t3-t2)*width*tangents[segment+1])
+	}
+
+	return lut
+}
+
+// posterizeLUT builds a lookup table that reduces every channel to the given number of evenly spaced levels.
+func posterizeLUT(levels int) [256]uint8 {
+	steps := float64(levels - 1)
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = clamp(math.Round(float64(i)/255*steps) * 255 / steps)
+	}
+	return lut
+}
+
+// luminance returns the perceived brightness of a color on a scale from 0 to 255.
+func luminance(c color.NRGBA) float64 {
+	return 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+}
+
+// mix linearly interpolates between two colors, with t = 0 returning a and t = 1 returning b.
+func mix(a, b color.NRGBA, t float64) color.NRGBA {
+	return color.NRGBA{
+		R: clamp(float64(a.R) + (float64(b.R)-float64(a.R))*t),
+		G: clamp(float64(a.G) + (float64(b.G)-float64(a.G))*t),
+		B: clamp(float64(a.B) + (float64(b.B)-float64(a.B))*t),
+		A: clamp(float64(a.A) + (float64(b.A)-float64(a.A))*t),
+	}
+}
+
+func clamp(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
+
+// rgbToHSL converts a color to hue, saturation and lightness, all on a scale from 0 to 1.
+func rgbToHSL(c color.NRGBA) (float64, float64, float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	maxC, minC := max(r, g, b), min(r, g, b)
+	l := (maxC + minC) / 2
+
+	if maxC == minC {
+		return 0, 0, l
+	}
+
+	d := maxC - minC
+	s := d / (maxC + minC)
+	if l > 0.5 {
+		s = d / (2 - maxC - minC)
+	}
+
+	var h float64
+	switch maxC {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+
+	return h / 6, s, l
+}
+
+func hslToRGB(h, s, l float64, alpha uint8) color.NRGBA {
+	if s == 0 {
+		v := clamp(l * 255)
+		return color.NRGBA{R: v, G: v, B: v, A: alpha}
+	}
+
+	q := l * (1 + s)
+	if l >= 0.5 {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+
+	return color.NRGBA{
+		R: clamp(hueToRGB(p, q, h+1.0/3) * 255),
+		G: clamp(hueToRGB(p, q, h) * 255),
+		B: clamp(hueToRGB(p, q, h-1.0/3) * 255),
+		A: alpha,
+	}
+}
+
+func hueToRGB(p, q, t float64) float64 {
+	if t < 0 {
+		t++
+	}
+	if t > 1 {
+		t--
+	}
+
+	switch {
+	case t < 1.0/6:
+		return p + (q-p)*6*t
+	case t < 1.0/2:
+		return q
+	case t < 2.0/3:
+		return p + (q-p)*(2.0/3-t)*6
+	default:
+		return p
+	}
+}
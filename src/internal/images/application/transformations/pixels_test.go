@@ -0,0 +1,69 @@
+package transformations
+
+import (
+	"image/color"
+	"testing"
+)
+
+func Test_curveLUT(t *testing.T) {
+	type args struct {
+		xs []float64
+		ys []float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[int]uint8
+	}{
+		{
+			name: "Identity",
+			args: args{xs: []float64{0, 255}, ys: []float64{0, 255}},
+			want: map[int]uint8{0: 0, 64: 64, 128: 128, 255: 255},
+		},
+		{
+			name: "Inverted",
+			args: args{xs: []float64{0, 255}, ys: []float64{255, 0}},
+			want: map[int]uint8{0: 255, 255: 0},
+		},
+		{
+			name: "Passes through control points",
+			args: args{xs: []float64{0, 64, 192, 255}, ys: []float64{0, 96, 160, 255}},
+			want: map[int]uint8{0: 0, 64: 96, 192: 160, 255: 255},
+		},
+		{
+			name: "Clamps outside of the points",
+			args: args{xs: []float64{50, 200}, ys: []float64{20, 230}},
+			want: map[int]uint8{0: 20, 50: 20, 200: 230, 255: 230},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := curveLUT(tt.args.xs, tt.args.ys)
+			for x, want := range tt.want {
+				if got[x] != want {
+					t.Errorf("curveLUT()[%d] = %v, want %v", x, got[x], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_hslRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		color color.NRGBA
+	}{
+		{name: "Red", color: color.NRGBA{R: 255, A: 255}},
+		{name: "Teal", color: color.NRGBA{G: 128, B: 128, A: 255}},
+		{name: "Gray", color: color.NRGBA{R: 100, G: 100, B: 100, A: 50}},
+		{name: "Light", color: color.NRGBA{R: 250, G: 200, B: 220, A: 255}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, s, l := rgbToHSL(tt.color)
+			if got := hslToRGB(h, s, l, tt.color.A); colorDistance(got, tt.color) > 1 {
+				t.Errorf("hslToRGB(rgbToHSL()) = %v, want %v", got, tt.color)
+			}
+		})
+	}
+}
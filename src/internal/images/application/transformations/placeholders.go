@@ -0,0 +1,148 @@
+package transformations
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/disintegration/imaging"
+	"image"
+	commonerrors "image-processing-service/src/internal/common/errors"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"strings"
+)
+
+const (
+	blurHashComponentsX = 4
+	blurHashComponentsY = 3
+	blurHashSampleSize  = 32
+	lqipSize            = 16
+	base83Characters    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+)
+
+// CreatePlaceholders computes the BlurHash and a tiny inlined copy of the image (LQIP), which clients can show while
+// the image itself is loading. Both are meant to be made from the preview, which is small enough to be cheap to decode.
+func (s *Service) CreatePlaceholders(imageBytes []byte) (string, string, error) {
+	img, _, err := deserialize(imageBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	lqip, err := createLQIP(img)
+	if err != nil {
+		return "", "", commonerrors.NewInternal(fmt.Sprintf("error creating LQIP: %v", err))
+	}
+
+	return createBlurHash(img), lqip, nil
+}
+
+// createBlurHash encodes the image as described at https://blurha.sh: the colors are transformed into a few cosine
+// components, which are quantized and written in base 83. The components do not need more detail than a small copy of
+// the image has, so the image is scaled down first.
+func createBlurHash(img image.Image) string {
+	src := toNRGBA(imaging.Fit(img, blurHashSampleSize, blurHashSampleSize, imaging.Box))
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+
+	var factors [blurHashComponentsX * blurHashComponentsY][3]float64
+	for j := range blurHashComponentsY {
+		for i := range blurHashComponentsX {
+			var sum [3]float64
+			for y := range height {
+				for x := range width {
+					basis := math.Cos(math.Pi*float64(i*x)/float64(width)) * math.Cos(math.Pi*float64(j*y)/float64(height))
+					c := src.NRGBAAt(src.Bounds().Min.X+x, src.Bounds().Min.Y+y)
+					sum[0] += basis * srgbToLinear(c.R)
+					sum[1] += basis * srgbToLinear(c.G)
+					sum[2] += basis * srgbToLinear(c.B)
+				}
+			}
+
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+			scale := normalisation / float64(width*height)
+			factors[j*blurHashComponentsX+i] = [3]float64{sum[0] * scale, sum[1] * scale, sum[2] * scale}
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encodeBase83((blurHashComponentsX-1)+(blurHashComponentsY-1)*9, 1))
+
+	maxAC := 0.0
+	for _, factor := range factors[1:] {
+		for _, v := range factor {
+			maxAC = math.Max(maxAC, math.Abs(v))
+		}
+	}
+	quantisedMax := int(math.Max(0, math.Min(82, math.Floor(maxAC*166-0.5))))
+	maxValue := float64(quantisedMax+1) / 166
+	hash.WriteString(encodeBase83(quantisedMax, 1))
+
+	dc := factors[0]
+	hash.WriteString(encodeBase83(linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4))
+
+	for _, factor := range factors[1:] {
+		var quantised [3]int
+		for k, v := range factor {
+			quantised[k] = int(math.Max(0, math.Min(18, math.Floor(signedPow(v/maxValue, 0.5)*9+9.5))))
+		}
+		hash.WriteString(encodeBase83(quantised[0]*19*19+quantised[1]*19+quantised[2], 2))
+	}
+
+	return hash.String()
+}
+
+// createLQIP scales the image down to a few pixels and returns it as a data URI. Opaque images are encoded as JPEG,
+// which is smaller, the rest as PNG to keep the transparency.
+func createLQIP(img image.Image) (string, error) {
+	small := imaging.Fit(img, lqipSize, lqipSize, imaging.Lanczos)
+
+	var buf bytes.Buffer
+	mimeType := "image/jpeg"
+	var err error
+	if small.Opaque() {
+		err = jpeg.Encode(&buf, small, &jpeg.Options{Quality: 50})
+	} else {
+		mimeType = "image/png"
+		err = png.Encode(&buf, small)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
+
+func encodeBase83(value, length int) string {
+	result := make([]byte, length)
+	for i := range length {
+		digit := (value / int(math.Pow(83, float64(length-i-1)))) % 83
+		result[i] = base83Characters[digit]
+	}
+
+	return string(result)
+}
+
+func srgbToLinear(value uint8) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signedPow(value, exponent float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exponent), value)
+}
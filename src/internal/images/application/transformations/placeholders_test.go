@@ -0,0 +1,94 @@
+package transformations
+
+import (
+	"encoding/base64"
+	"github.com/disintegration/imaging"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func Test_createBlurHash(t *testing.T) {
+	tests := []struct {
+		name   string
+		img    image.Image
+		wantDC string
+	}{
+		{
+			name:   "Red",
+			img:    imaging.New(40, 30, color.NRGBA{R: 255, A: 255}),
+			wantDC: "TI:j",
+		},
+		{
+			name:   "Black",
+			img:    imaging.New(10, 10, color.NRGBA{A: 255}),
+			wantDC: "0000",
+		},
+		{
+			name:   "White",
+			img:    imaging.New(64, 64, color.NRGBA{R: 255, G: 255, B: 255, A: 255}),
+			wantDC: "TSUA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := createBlurHash(tt.img)
+			if len(got) != 4+2*blurHashComponentsX*blurHashComponentsY {
+				t.Fatalf("createBlurHash() = %v, has length %d", got, len(got))
+			}
+			// The first character encodes the number of components, which is 4x3.
+			if got[0] != 'L' {
+				t.Errorf("createBlurHash() = %v, want it to start with L", got)
+			}
+			if got[2:6] != tt.wantDC {
+				t.Errorf("createBlurHash() average color = %v, want %v", got[2:6], tt.wantDC)
+			}
+		})
+	}
+}
+
+func Test_createLQIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		img        image.Image
+		wantPrefix string
+		wantSize   image.Point
+	}{
+		{
+			name:       "Opaque image",
+			img:        imaging.New(200, 100, color.NRGBA{G: 255, A: 255}),
+			wantPrefix: "data:image/jpeg;base64,",
+			wantSize:   image.Pt(16, 8),
+		},
+		{
+			name:       "Transparent image",
+			img:        imaging.New(50, 100, color.NRGBA{G: 255, A: 100}),
+			wantPrefix: "data:image/png;base64,",
+			wantSize:   image.Pt(8, 16),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := createLQIP(tt.img)
+			if err != nil {
+				t.Fatalf("createLQIP() error = %v", err)
+			}
+			if !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Fatalf("createLQIP() = %v, want prefix %v", got, tt.wantPrefix)
+			}
+
+			data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(got, tt.wantPrefix))
+			if err != nil {
+				t.Fatalf("createLQIP() is not valid base64: %v", err)
+			}
+			img, _, err := deserialize(data)
+			if err != nil {
+				t.Fatalf("createLQIP() is not a valid image: %v", err)
+			}
+			if img.Bounds().Size() != tt.wantSize {
+				t.Errorf("createLQIP() size = %v, want %v", img.Bounds().Size(), tt.wantSize)
+			}
+		})
+	}
+}
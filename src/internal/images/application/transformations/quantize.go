@@ -0,0 +1,141 @@
+package transformations
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"slices"
+)
+
+const (
+	maxPaletteSize   = 256
+	maxSampledPixels = 1 << 16
+)
+
+// toPaletted quantizes the image to a palette made for it by median cut. If the image has transparent pixels, one entry
+// of the palette is reserved for them, since GIF only knows fully transparent and fully opaque pixels.
+func toPaletted(img image.Image) *image.Paletted {
+	pixels, transparent := samplePixels(img)
+
+	size := maxPaletteSize
+	if transparent {
+		size--
+	}
+
+	boxes := medianCut(pixels, size)
+	palette := make(color.Palette, 0, maxPaletteSize)
+	for _, box := range boxes {
+		palette = append(palette, box.average())
+	}
+	if transparent || len(palette) == 0 {
+		palette = append(palette, color.NRGBA{})
+	}
+
+	bounds := img.Bounds()
+	paletted := image.NewPaletted(image.Rect(0, 0, bounds.Dx(), bounds.Dy()), palette)
+	// Dithering is left out on purpose, as the pattern would change from frame to frame and make animations flicker.
+	draw.Draw(paletted, paletted.Bounds(), img, bounds.Min, draw.Src)
+
+	return paletted
+}
+
+// samplePixels returns the opaque pixels of the image, taking only every n-th one of large images, and reports whether
+// the image has any transparent pixels.
+func samplePixels(img image.Image) ([]color.NRGBA, bool) {
+	src := toNRGBA(img)
+	bounds := src.Bounds()
+	step := max(1, bounds.Dx()*bounds.Dy()/maxSampledPixels)
+
+	pixels := make([]color.NRGBA, 0, min(bounds.Dx()*bounds.Dy(), maxSampledPixels+1))
+	transparent := false
+	for i := 0; i < bounds.Dx()*bounds.Dy(); i++ {
+		c := src.NRGBAAt(bounds.Min.X+i%bounds.Dx(), bounds.Min.Y+i/bounds.Dx())
+		if c.A < 128 {
+			transparent = true
+			continue
+		}
+		if i%step == 0 {
+			pixels = append(pixels, c)
+		}
+	}
+
+	return pixels, transparent
+}
+
+// colorBox is a group of similar colors, as produced by median cut.
+type colorBox []color.NRGBA
+
+func (b colorBox) average() color.NRGBA {
+	var r, g, bl int
+	for _, c := range b {
+		r += int(c.R)
+		g += int(c.G)
+		bl += int(c.B)
+	}
+
+	return color.NRGBA{R: uint8(r / len(b)), G: uint8(g / len(b)), B: uint8(bl / len(b)), A: 255}
+}
+
+// widestChannel returns the channel (0 for red, 1 for green, 2 for blue) with the largest range of values in the box
+// together with that range.
+func (b colorBox) widestChannel() (int, int) {
+	low := [3]uint8{255, 255, 255}
+	high := [3]uint8{}
+	for _, c := range b {
+		for i, v := range [3]uint8{c.R, c.G, c.B} {
+			low[i] = min(low[i], v)
+			high[i] = max(high[i], v)
+		}
+	}
+
+	channel := 0
+	for i := range 3 {
+		if high[i]-low[i] > high[channel]-low[channel] {
+			channel = i
+		}
+	}
+
+	return channel, int(high[channel] - low[channel])
+}
+
+// medianCut splits the colors into at most count boxes by repeatedly cutting the box with the widest range of values
+// in half at the median of that channel.
+func medianCut(pixels []color.NRGBA, count int) []colorBox {
+	if len(pixels) == 0 {
+		return nil
+	}
+
+	boxes := []colorBox{slices.Clone(pixels)}
+	for len(boxes) < count {
+		widest, widestChannel, widestRange := -1, 0, 0
+		for i, box := range boxes {
+			channel, valueRange := box.widestChannel()
+			if len(box) > 1 && valueRange > widestRange {
+				widest, widestChannel, widestRange = i, channel, valueRange
+			}
+		}
+		if widest == -1 {
+			break
+		}
+
+		box := boxes[widest]
+		slices.SortFunc(box, func(a, b color.NRGBA) int {
+			return int(channelValue(a, widestChannel)) - int(channelValue(b, widestChannel))
+		})
+		boxes[widest] = box[:len(box)/2]
+		boxes = append(boxes, box[len(box)/2:])
+	}
+
+	return boxes
+}
+
+func channelValue(c color.NRGBA, channel int) uint8 {
+	switch channel {
+	case 0:
+		return c.R
+	case 1:
+		return c.G
+	default:
+		return c.B
+	}
+}
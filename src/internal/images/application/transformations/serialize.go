@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"image"
 	commonerrors "image-processing-service/src/internal/common/errors"
+	"image/gif"
 	"image/jpeg"
 	"image/png"
 )
@@ -21,6 +22,10 @@ func serialize(img image.Image, format string) ([]byte, error) {
 		if err := png.Encode(&buf, img); err != nil {
 			return nil, commonerrors.NewInternal("failed to encode image as PNG")
 		}
+	case "gif":
+		if err := gif.Encode(&buf, toPaletted(img), nil); err != nil {
+			return nil, commonerrors.NewInternal("failed to encode image as GIF")
+		}
 	default:
 		return nil, commonerrors.NewInternal("unsupported image format")
 	}
@@ -36,3 +41,56 @@ func deserialize(data []byte) (image.Image, string, error) {
 
 	return img, format, nil
 }
+
+func deserializeAll(imagesBytes [][]byte) ([]image.Image, error) {
+	images := make([]image.Image, len(imagesBytes))
+	for i, imageBytes := range imagesBytes {
+		img, _, err := deserialize(imageBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize image: %w", err)
+		}
+		images[i] = img
+	}
+
+	return images, nil
+}
+
+// serializeAnimation encodes the frames as an animated GIF, quantizing every frame to its own palette.
+func serializeAnimation(anim *animation) ([]byte, error) {
+	bounds := anim.frames[0].Bounds()
+	g := &gif.GIF{
+		Image:     make([]*image.Paletted, len(anim.frames)),
+		Delay:     anim.delays,
+		Disposal:  anim.disposals,
+		LoopCount: anim.loopCount,
+		Config:    image.Config{Width: bounds.Dx(), Height: bounds.Dy()},
+	}
+	for i, frame := range anim.frames {
+		g.Image[i] = toPaletted(frame)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		return nil, commonerrors.NewInternal("failed to encode image as GIF")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func deserializeAnimation(data []byte) (*animation, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("error decoding image: %v", err))
+	}
+
+	bounds, err := animationBounds(g)
+	if err != nil {
+		return nil, commonerrors.NewInvalidInput(fmt.Sprintf("invalid animation: %v", err))
+	}
+
+	return newAnimation(g, bounds), nil
+}
+
+func isGIF(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a"))
+}
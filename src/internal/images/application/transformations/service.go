@@ -1,11 +1,14 @@
 package transformations
 
 import (
+	"bytes"
 	"fmt"
 	"image"
+	commonerrors "image-processing-service/src/internal/common/errors"
 	"image-processing-service/src/internal/common/metrics"
 	"image-processing-service/src/internal/images/domain"
 	"log/slog"
+	"slices"
 	"sync"
 )
 
@@ -37,10 +40,76 @@ func (s *Service) CreatePreview(bytes []byte) ([]byte, error) {
 				domain.Width:  200,
 				domain.Height: 200,
 			},
+			Parameters: map[domain.TransformationParameterType]string{
+				domain.Mode: string(domain.ResizeModeFit),
+			},
 		},
 	})
 }
 
+// CreateVariants scales the image down to fit into squares of the given sizes, keeping its aspect ratio. Images that
+// already fit are left at their size. The image is decoded only once for all the variants.
+func (s *Service) CreateVariants(imageBytes []byte, sizes []int) ([][]byte, error) {
+	source, err := assemble(imageBytes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([][]byte, len(sizes))
+	for i, size := range sizes {
+		packet := source.clone([]domain.Transformation{
+			{
+				Type: domain.Resize,
+				Options: map[domain.TransformationOptionType]float64{
+					domain.Width:  float64(size),
+					domain.Height: float64(size),
+				},
+				Parameters: map[domain.TransformationParameterType]string{
+					domain.Mode: string(domain.ResizeModeFit),
+				},
+			},
+		})
+
+		go func() {
+			metrics.ImageProcessingOperationsTotal.WithLabelValues(string(domain.Resize)).Inc()
+		}()
+
+		s.workerCoordinator.process(packet)
+
+		variants[i], err = deassemble(packet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return variants, nil
+}
+
+// Dimensions reads the size of the image without decoding all of it.
+func (s *Service) Dimensions(imageBytes []byte) (int, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(imageBytes))
+	if err != nil {
+		return 0, 0, commonerrors.NewInternal(fmt.Sprintf("error decoding image: %v", err))
+	}
+
+	return config.Width, config.Height, nil
+}
+
+// Properties reads the format and the dimensions of the image without decoding all of it.
+func (s *Service) Properties(imageBytes []byte) (domain.ImageProperties, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(imageBytes))
+	if err != nil {
+		return domain.ImageProperties{}, commonerrors.NewInternal(fmt.Sprintf("error decoding image: %v", err))
+	}
+
+	return domain.ImageProperties{
+		Format: format,
+		Size:   int64(len(imageBytes)),
+		Width:  config.Width,
+		Height: config.Height,
+	}, nil
+}
+
 func (s *Service) Apply(imageBytes []byte, transformations []domain.Transformation) ([]byte, error) {
 	packet, err := assemble(imageBytes, transformations)
 	if err != nil {
@@ -58,15 +127,119 @@ func (s *Service) Apply(imageBytes []byte, transformations []domain.Transformati
 	return deassemble(packet)
 }
 
+// Compose renders a new image from several source images. The result is always a PNG, since the sources may differ in
+// format and the background may be transparent.
+func (s *Service) Compose(imagesBytes [][]byte, composition domain.Composition) ([]byte, error) {
+	sources, err := deserializeAll(imagesBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.render("compose", func() (image.Image, error) {
+		return compose(sources, composition)
+	})
+}
+
+// CreateSpriteSheet packs the images into a single atlas and returns it together with a document describing the
+// position of every image.
+func (s *Service) CreateSpriteSheet(
+	imagesBytes [][]byte,
+	names []string,
+	padding,
+	maxSize int,
+) ([]byte, *domain.SheetDocument, error) {
+	sources, err := deserializeAll(imagesBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document := &domain.SheetDocument{}
+	sheetBytes, err := s.render("sprite_sheet", func() (image.Image, error) {
+		var img image.Image
+		img, document.Frames = createSpriteSheet(sources, names, padding, maxSize)
+		document.Width, document.Height = img.Bounds().Dx(), img.Bounds().Dy()
+		return img, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sheetBytes, document, nil
+}
+
+// CreateContactSheet lays the images out in a grid with their names under them and returns the sheet together with a
+// document describing the position of every image.
+func (s *Service) CreateContactSheet(
+	imagesBytes [][]byte,
+	names []string,
+	columns int,
+) ([]byte, *domain.SheetDocument, error) {
+	sources, err := deserializeAll(imagesBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document := &domain.SheetDocument{}
+	sheetBytes, err := s.render("contact_sheet", func() (image.Image, error) {
+		var img image.Image
+		img, document.Frames = createContactSheet(sources, names, columns)
+		document.Width, document.Height = img.Bounds().Dx(), img.Bounds().Dy()
+		return img, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sheetBytes, document, nil
+}
+
+// render runs an operation that creates a new image in the worker pool and serializes the result as PNG.
+func (s *Service) render(operation string, fn func() (image.Image, error)) ([]byte, error) {
+	packet := &transformationPacket{
+		format:       "png",
+		render:       fn,
+		responseChan: make(chan image.Image, 1),
+		errChan:      make(chan error, 1),
+	}
+
+	go func() {
+		metrics.ImageProcessingOperationsTotal.WithLabelValues(operation).Inc()
+	}()
+
+	s.workerCoordinator.process(packet)
+
+	return deassemble(packet)
+}
+
+// transformationPacket is a single job for the workers. If it carries a render function, the image is first created
+// by it, and the transformations are applied to the result. Animated images carry their frames in the animation
+// instead of the image, and the transformations are applied to every frame.
 type transformationPacket struct {
 	img             image.Image
+	animation       *animation
 	format          string
+	render          func() (image.Image, error)
 	transformations []domain.Transformation
 	responseChan    chan image.Image
 	errChan         chan error
 }
 
 func assemble(imageBytes []byte, transformations []domain.Transformation) (*transformationPacket, error) {
+	if isGIF(imageBytes) {
+		anim, err := deserializeAnimation(imageBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize animation: %w", err)
+		}
+
+		return &transformationPacket{
+			animation:       anim,
+			format:          "gif",
+			transformations: transformations,
+			responseChan:    make(chan image.Image, 1),
+			errChan:         make(chan error, 1),
+		}, nil
+	}
+
 	img, format, err := deserialize(imageBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize image: %w", err)
@@ -81,9 +254,36 @@ func assemble(imageBytes []byte, transformations []domain.Transformation) (*tran
 	}, nil
 }
 
+// clone returns a packet that applies the given transformations to the same decoded image. It is only meant for
+// transformations that create new images rather than changing them, such as resizing, so the pixels are shared and
+// only the slices of the animation are copied.
+func (p *transformationPacket) clone(transformations []domain.Transformation) *transformationPacket {
+	packet := &transformationPacket{
+		img:             p.img,
+		format:          p.format,
+		transformations: transformations,
+		responseChan:    make(chan image.Image, 1),
+		errChan:         make(chan error, 1),
+	}
+
+	if p.animation != nil {
+		packet.animation = &animation{
+			frames:    slices.Clone(p.animation.frames),
+			delays:    slices.Clone(p.animation.delays),
+			disposals: slices.Clone(p.animation.disposals),
+			loopCount: p.animation.loopCount,
+		}
+	}
+
+	return packet
+}
+
 func deassemble(packet *transformationPacket) ([]byte, error) {
 	select {
 	case resultImg := <-packet.responseChan:
+		if packet.animation != nil {
+			return serializeAnimation(packet.animation)
+		}
 		return serialize(resultImg, packet.format)
 	case err := <-packet.errChan:
 		return nil, err
@@ -129,7 +329,7 @@ func newWorker(jobQueue chan *transformationPacket) *worker {
 
 func (w *worker) start() {
 	for packet := range w.jobQueue {
-		err := applyTransformations(packet)
+		err := processPacket(packet)
 		if err != nil {
 			packet.errChan <- err
 		} else {
@@ -140,39 +340,148 @@ func (w *worker) start() {
 	}
 }
 
+func processPacket(packet *transformationPacket) error {
+	if packet.render != nil {
+		img, err := packet.render()
+		if err != nil {
+			return commonerrors.NewInvalidInput(fmt.Sprintf("error rendering image: %v", err))
+		}
+		packet.img = img
+	}
+
+	return applyTransformations(packet)
+}
+
 func applyTransformations(packet *transformationPacket) error {
-	var err error
 	for _, t := range packet.transformations {
-		switch t.Type {
-		case domain.Resize:
-			packet.img, err = resize(packet.img, t.Options)
-		case domain.Crop:
-			packet.img, err = crop(packet.img, t.Options)
-		case domain.Rotate:
-			packet.img, err = rotate(packet.img, t.Options)
-		case domain.Grayscale:
-			packet.img = grayscale(packet.img)
-		case domain.Sepia:
-			packet.img = sepia(packet.img)
-		case domain.Invert:
-			packet.img = invert(packet.img)
-		case domain.AdjustBrightness:
-			packet.img, err = adjustBrightness(packet.img, t.Options)
-		case domain.AdjustContrast:
-			packet.img, err = adjustContrast(packet.img, t.Options)
-		case domain.AdjustSaturation:
-			packet.img, err = adjustSaturation(packet.img, t.Options)
-		case domain.Blur:
-			packet.img, err = blur(packet.img, t.Options)
-		case domain.Sharpen:
-			packet.img, err = sharpen(packet.img, t.Options)
+		var err error
+		switch {
+		case isAnimationTransformation(t.Type):
+			err = applyAnimationTransformation(packet, t)
+		case packet.animation != nil:
+			if t.Type == domain.RemoveBackground {
+				t, err = withoutFeather(t)
+				if err != nil {
+					break
+				}
+			}
+			for i, frame := range packet.animation.frames {
+				packet.animation.frames[i], err = applyTransformation(frame, t)
+				if err != nil {
+					break
+				}
+			}
 		default:
-			err = fmt.Errorf("unsupported transformation type: %v", t.Type)
+			packet.img, err = applyTransformation(packet.img, t)
 		}
 
 		if err != nil {
-			return fmt.Errorf("error applying transformation %v: %w", t.Type, err)
+			return commonerrors.NewInvalidInput(fmt.Sprintf("error applying transformation %v: %v", t.Type, err))
+		}
+
+		// The result relies on transparency, so a still image is stored as PNG whatever the source format was. GIF
+		// supports transparency too, so animations stay animated, though only with hard edges.
+		if t.Type == domain.RemoveBackground && packet.animation == nil {
+			packet.format = "png"
 		}
 	}
 	return nil
 }
+
+// applyTransformation applies a single transformation to a single image, which is either a still image or one frame
+// of an animation.
+func applyTransformation(img image.Image, t domain.Transformation) (image.Image, error) {
+	var err error
+	switch t.Type {
+	case domain.Resize:
+		img, err = resize(img, t.Options, t.Parameters)
+	case domain.Crop:
+		img, err = crop(img, t.Options, t.Parameters)
+	case domain.Rotate:
+		img, err = rotate(img, t.Options, t.Parameters)
+	case domain.FlipHorizontal:
+		img = flipHorizontal(img)
+	case domain.FlipVertical:
+		img = flipVertical(img)
+	case domain.Transpose:
+		img = transpose(img)
+	case domain.Transverse:
+		img = transverse(img)
+	case domain.Pad:
+		img, err = pad(img, t.Options, t.Parameters)
+	case domain.Border:
+		img, err = border(img, t.Options, t.Parameters)
+	case domain.ExtendCanvas:
+		img, err = extendCanvas(img, t.Options, t.Parameters)
+	case domain.Trim:
+		img, err = trim(img, t.Options, t.Parameters)
+	case domain.Redact:
+		img, err = redact(img, t.Options, t.Parameters)
+	case domain.Vignette:
+		img, err = vignette(img, t.Options)
+	case domain.Pixelate:
+		img, err = pixelate(img, t.Options)
+	case domain.Noise:
+		img, err = noise(img, t.Options, t.Parameters)
+	case domain.PopArt:
+		img, err = popArt(img, t.Options)
+	case domain.OilPaint:
+		img, err = oilPaint(img, t.Options)
+	case domain.RemoveBackground:
+		img, err = removeBackgroundColor(img, t.Options, t.Parameters)
+	case domain.Grayscale:
+		img = grayscale(img)
+	case domain.Sepia:
+		img = sepia(img)
+	case domain.Invert:
+		img = invert(img)
+	case domain.AdjustBrightness:
+		img, err = adjustBrightness(img, t.Options)
+	case domain.AdjustContrast:
+		img, err = adjustContrast(img, t.Options)
+	case domain.AdjustSaturation:
+		img, err = adjustSaturation(img, t.Options)
+	case domain.AdjustGamma:
+		img, err = adjustGamma(img, t.Options)
+	case domain.AdjustHue:
+		img, err = adjustHue(img, t.Options)
+	case domain.AdjustLevels:
+		img, err = adjustLevels(img, t.Options, t.Parameters)
+	case domain.AdjustCurves:
+		img, err = adjustCurves(img, t.Parameters, t.Values)
+	case domain.Threshold:
+		img, err = threshold(img, t.Options)
+	case domain.Posterize:
+		img, err = posterize(img, t.Options)
+	case domain.Tint:
+		img, err = tint(img, t.Options, t.Parameters)
+	case domain.Colorize:
+		img, err = colorize(img, t.Options, t.Parameters)
+	case domain.Duotone:
+		img, err = duotone(img, t.Parameters)
+	case domain.AutoEnhance:
+		img, err = autoEnhance(img, t.Options)
+	case domain.Equalize:
+		img, err = equalize(img, t.Options)
+	case domain.AutoWhiteBalance:
+		img, err = autoWhiteBalance(img, t.Options)
+	case domain.Convolve:
+		img, err = convolve(img, t.Options, t.Values)
+	case domain.EdgeDetect:
+		img, err = edgeDetect(img, t.Parameters)
+	case domain.Emboss:
+		img = emboss(img)
+	case domain.Median:
+		img, err = median(img, t.Options)
+	case domain.UnsharpMask:
+		img, err = unsharpMask(img, t.Options)
+	case domain.Blur:
+		img, err = blur(img, t.Options)
+	case domain.Sharpen:
+		img, err = sharpen(img, t.Options)
+	default:
+		err = fmt.Errorf("unsupported transformation type: %v", t.Type)
+	}
+
+	return img, err
+}
@@ -0,0 +1,126 @@
+package transformations
+
+import (
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"math"
+	"slices"
+)
+
+const (
+	contactSheetColumns     = 5
+	contactSheetSpacing     = 10
+	contactSheetLabelHeight = 20
+)
+
+// createSpriteSheet packs the images into rows ("shelves"), placing the tallest images first. The width of the sheet is
+// chosen so that the result is roughly square. If maxSize is positive, larger images are scaled down to fit into it.
+func createSpriteSheet(sources []image.Image, names []string, padding, maxSize int) (image.Image, []domain.SheetFrame) {
+	sprites := make([]image.Image, len(sources))
+	area, widest := 0, 0
+	for i, src := range sources {
+		sprites[i] = src
+		if maxSize > 0 {
+			sprites[i] = imaging.Fit(src, maxSize, maxSize, imaging.Lanczos)
+		}
+		size := sprites[i].Bounds().Size()
+		area += (size.X + padding) * (size.Y + padding)
+		widest = max(widest, size.X)
+	}
+
+	order := make([]int, len(sprites))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return sprites[b].Bounds().Dy() - sprites[a].Bounds().Dy()
+	})
+
+	sheetWidth := max(widest+2*padding, int(math.Ceil(math.Sqrt(float64(area))))+padding)
+	frames := make([]domain.SheetFrame, len(sprites))
+	x, y, shelfHeight, usedWidth := padding, padding, 0, 0
+	for _, i := range order {
+		size := sprites[i].Bounds().Size()
+		if x+size.X+padding > sheetWidth && x > padding {
+			x, y = padding, y+shelfHeight+padding
+			shelfHeight = 0
+		}
+
+		frames[i] = domain.SheetFrame{Name: names[i], X: x, Y: y, Width: size.X, Height: size.Y}
+		x += size.X + padding
+		shelfHeight = max(shelfHeight, size.Y)
+		usedWidth = max(usedWidth, x)
+	}
+
+	sheet := imaging.New(usedWidth, y+shelfHeight+padding, color.Transparent)
+	for i, frame := range frames {
+		sheet = imaging.Paste(sheet, sprites[i], image.Pt(frame.X, frame.Y))
+	}
+
+	return sheet, frames
+}
+
+// createContactSheet places the images into equally sized cells with the name of every image printed under it. The
+// cells are as large as the largest image, so the images are expected to be previews rather than full images.
+func createContactSheet(sources []image.Image, names []string, columns int) (image.Image, []domain.SheetFrame) {
+	if columns < 1 {
+		columns = contactSheetColumns
+	}
+	columns = min(columns, len(sources))
+	rows := (len(sources) + columns - 1) / columns
+
+	cellWidth, cellHeight := 0, 0
+	for _, src := range sources {
+		cellWidth = max(cellWidth, src.Bounds().Dx())
+		cellHeight = max(cellHeight, src.Bounds().Dy())
+	}
+
+	sheet := imaging.New(
+		columns*(cellWidth+contactSheetSpacing)+contactSheetSpacing,
+		rows*(cellHeight+contactSheetLabelHeight+contactSheetSpacing)+contactSheetSpacing,
+		color.White,
+	)
+
+	frames := make([]domain.SheetFrame, len(sources))
+	for i, src := range sources {
+		cell := image.Rect(0, 0, cellWidth, cellHeight).Add(image.Pt(
+			contactSheetSpacing+(i%columns)*(cellWidth+contactSheetSpacing),
+			contactSheetSpacing+(i/columns)*(cellHeight+contactSheetLabelHeight+contactSheetSpacing),
+		))
+		position := anchorPoint(cell, src.Bounds().Size(), imaging.Center)
+		sheet = imaging.Overlay(sheet, src, position, 1)
+		drawLabel(sheet, names[i], image.Rect(cell.Min.X, cell.Max.Y, cell.Max.X, cell.Max.Y+contactSheetLabelHeight))
+
+		frames[i] = domain.SheetFrame{
+			Name:   names[i],
+			X:      position.X,
+			Y:      position.Y,
+			Width:  src.Bounds().Dx(),
+			Height: src.Bounds().Dy(),
+		}
+	}
+
+	return sheet, frames
+}
+
+// drawLabel prints the text centered in the area, cutting it short with an ellipsis if it does not fit.
+func drawLabel(img *image.NRGBA, text string, area image.Rectangle) {
+	drawer := &font.Drawer{Dst: img, Src: image.NewUniform(color.Black), Face: basicfont.Face7x13}
+
+	runes := []rune(text)
+	for len(runes) > 0 && drawer.MeasureString(string(runes)).Ceil() > area.Dx() {
+		runes = runes[:len(runes)-1]
+		if len(runes) > 0 {
+			runes[len(runes)-1] = '…'
+		}
+	}
+
+	width := drawer.MeasureString(string(runes)).Ceil()
+	drawer.Dot = fixed.P(area.Min.X+(area.Dx()-width)/2, area.Min.Y+(area.Dy()+basicfont.Face7x13.Ascent)/2)
+	drawer.DrawString(string(runes))
+}
@@ -0,0 +1,73 @@
+package transformations
+
+import (
+	"image"
+	"testing"
+)
+
+func Test_createSpriteSheet(t *testing.T) {
+	sources := []image.Image{
+		image.NewNRGBA(image.Rect(0, 0, 10, 10)),
+		image.NewNRGBA(image.Rect(0, 0, 30, 20)),
+		image.NewNRGBA(image.Rect(0, 0, 5, 40)),
+		image.NewNRGBA(image.Rect(0, 0, 100, 50)),
+	}
+	names := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name    string
+		padding int
+		maxSize int
+	}{
+		{name: "Without padding", padding: 0, maxSize: 0},
+		{name: "With padding", padding: 3, maxSize: 0},
+		{name: "With max size", padding: 1, maxSize: 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet, frames := createSpriteSheet(sources, names, tt.padding, tt.maxSize)
+
+			for i, frame := range frames {
+				if frame.Name != names[i] {
+					t.Errorf("createSpriteSheet() frame %d name = %v, want %v", i, frame.Name, names[i])
+				}
+
+				if tt.maxSize > 0 && (frame.Width > tt.maxSize || frame.Height > tt.maxSize) {
+					t.Errorf("createSpriteSheet() frame %d size = %dx%d, exceeds %d", i, frame.Width, frame.Height, tt.maxSize)
+				}
+
+				rect := image.Rect(frame.X, frame.Y, frame.X+frame.Width, frame.Y+frame.Height)
+				if !rect.Inset(-tt.padding).In(sheet.Bounds()) {
+					t.Errorf("createSpriteSheet() frame %d = %v, outside of sheet %v", i, rect, sheet.Bounds())
+				}
+
+				for j, other := range frames[:i] {
+					otherRect := image.Rect(other.X, other.Y, other.X+other.Width, other.Y+other.Height)
+					if rect.Overlaps(otherRect.Inset(-tt.padding)) {
+						t.Errorf("createSpriteSheet() frame %d = %v, overlaps frame %d = %v", i, rect, j, otherRect)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_createContactSheet(t *testing.T) {
+	sources := []image.Image{
+		image.NewNRGBA(image.Rect(0, 0, 20, 10)),
+		image.NewNRGBA(image.Rect(0, 0, 10, 20)),
+		image.NewNRGBA(image.Rect(0, 0, 20, 20)),
+	}
+	names := []string{"short", "a-name-that-is-far-too-long-to-fit", "c"}
+
+	sheet, frames := createContactSheet(sources, names, 2)
+
+	wantSize := image.Pt(2*(20+contactSheetSpacing)+contactSheetSpacing, 2*(20+contactSheetLabelHeight+contactSheetSpacing)+contactSheetSpacing)
+	if sheet.Bounds().Size() != wantSize {
+		t.Errorf("createContactSheet() size = %v, want %v", sheet.Bounds().Size(), wantSize)
+	}
+
+	if frames[1].X != 2*contactSheetSpacing+20+5 || frames[2].Y != 2*contactSheetSpacing+20+contactSheetLabelHeight {
+		t.Errorf("createContactSheet() frames = %+v, images are not centered in their cells", frames)
+	}
+}
@@ -9,7 +9,43 @@ import (
 	"math"
 )
 
-func resize(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+func resize(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	filter, err := parseFilter(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := domain.ResizeMode(parameters[domain.Mode])
+	if mode == "" || mode == domain.ResizeModeStretch {
+		// Omitting one of the dimensions scales the image proportionally to the other one.
+		width, hasWidth := options[domain.Width]
+		height, hasHeight := options[domain.Height]
+		if !hasWidth && !hasHeight {
+			return nil, fmt.Errorf("resize option 'width' or 'height' is required and must be a number")
+		}
+		if (hasWidth && int(width) < 1) || (hasHeight && int(height) < 1) {
+			return nil, fmt.Errorf("resize options 'width' and 'height' must be positive")
+		}
+
+		bounds := img.Bounds()
+		if !hasWidth {
+			width = math.Max(1, math.Round(float64(int(height)*bounds.Dx())/float64(bounds.Dy())))
+		}
+		if !hasHeight {
+			height = math.Max(1, math.Round(float64(int(width)*bounds.Dy())/float64(bounds.Dx())))
+		}
+
+		if err := validateImageDimensions(int(width), int(height)); err != nil {
+			return nil, err
+		}
+
+		return imaging.Resize(img, int(width), int(height), filter), nil
+	}
+
 	width, ok := options[domain.Width]
 	if !ok {
 		return nil, fmt.Errorf("resize option 'width' is required and must be a number")
@@ -20,10 +56,158 @@ func resize(img image.Image, options map[domain.TransformationOptionType]float64
 		return nil, fmt.Errorf("resize option 'height' is required and must be a number")
 	}
 
-	return imaging.Resize(img, int(width), int(height), imaging.Lanczos), nil
+	if int(width) < 1 || int(height) < 1 {
+		return nil, fmt.Errorf("resize options 'width' and 'height' must be positive")
+	}
+
+	if err := validateImageDimensions(int(width), int(height)); err != nil {
+		return nil, err
+	}
+
+	switch mode {
+	case domain.ResizeModeFit:
+		return imaging.Fit(img, int(width), int(height), filter), nil
+	case domain.ResizeModeFill:
+		anchor, err := parseAnchor(parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		return imaging.Fill(img, int(width), int(height), anchor, filter), nil
+	case domain.ResizeModePad:
+		anchor, err := parseAnchor(parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		background, err := parseColor(parameters, domain.Color, color.NRGBA{})
+		if err != nil {
+			return nil, err
+		}
+
+		canvas := imaging.New(int(width), int(height), background)
+		fitted := imaging.Fit(img, int(width), int(height), filter)
+
+		return imaging.Paste(canvas, fitted, anchorPoint(canvas.Bounds(), fitted.Bounds().Size(), anchor)), nil
+	default:
+		return nil, fmt.Errorf("resize mode '%s' is not supported", mode)
+	}
+}
+
+func crop(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	switch domain.CropMode(parameters[domain.Mode]) {
+	case "", domain.CropModeAnchor:
+		return cropAnchor(img, options, parameters)
+	case domain.CropModeRectangle:
+		return cropRectangle(img, options)
+	case domain.CropModeAspectRatio:
+		return cropAspectRatio(img, options, parameters)
+	case domain.CropModePercentage:
+		return cropPercentage(img, options)
+	default:
+		return nil, fmt.Errorf("crop mode '%s' is not supported", parameters[domain.Mode])
+	}
+}
+
+func cropAnchor(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	width, ok := options[domain.Width]
+	if !ok {
+		return nil, fmt.Errorf("crop option 'width' is required and must be a number")
+	}
+
+	height, ok := options[domain.Height]
+	if !ok {
+		return nil, fmt.Errorf("crop option 'height' is required and must be a number")
+	}
+
+	anchor, err := parseAnchor(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	if int(width) < 1 || int(width) > bounds.Dx() || int(height) < 1 || int(height) > bounds.Dy() {
+		return nil, fmt.Errorf("crop size %dx%d is outside of the image size %dx%d", int(width), int(height), bounds.Dx(), bounds.Dy())
+	}
+
+	return imaging.CropAnchor(img, int(width), int(height), anchor), nil
+}
+
+func cropRectangle(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	x, ok := options[domain.X]
+	if !ok {
+		return nil, fmt.Errorf("crop option 'x' is required and must be a number")
+	}
+
+	y, ok := options[domain.Y]
+	if !ok {
+		return nil, fmt.Errorf("crop option 'y' is required and must be a number")
+	}
+
+	width, ok := options[domain.Width]
+	if !ok {
+		return nil, fmt.Errorf("crop option 'width' is required and must be a number")
+	}
+
+	height, ok := options[domain.Height]
+	if !ok {
+		return nil, fmt.Errorf("crop option 'height' is required and must be a number")
+	}
+
+	rect := image.Rect(int(x), int(y), int(x)+int(width), int(y)+int(height))
+	err := validateRectangle(img.Bounds(), rect)
+	if err != nil {
+		return nil, err
+	}
+
+	return imaging.Crop(img, rect.Add(img.Bounds().Min)), nil
+}
+
+func cropAspectRatio(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	aspectWidth, ok := options[domain.AspectWidth]
+	if !ok || aspectWidth <= 0 {
+		return nil, fmt.Errorf("crop option 'aspect_width' is required and must be a positive number")
+	}
+
+	aspectHeight, ok := options[domain.AspectHeight]
+	if !ok || aspectHeight <= 0 {
+		return nil, fmt.Errorf("crop option 'aspect_height' is required and must be a positive number")
+	}
+
+	anchor, err := parseAnchor(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	// The crop is the largest rectangle of the requested aspect ratio that still fits inside the image.
+	bounds := img.Bounds()
+	ratio := aspectWidth / aspectHeight
+	width, height := bounds.Dx(), bounds.Dy()
+	if float64(width)/float64(height) > ratio {
+		width = int(math.Round(float64(height) * ratio))
+	} else {
+		height = int(math.Round(float64(width) / ratio))
+	}
+
+	return imaging.CropAnchor(img, max(width, 1), max(height, 1), anchor), nil
 }
 
-func crop(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+func cropPercentage(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	x := options[domain.X]
+	y := options[domain.Y]
+
 	width, ok := options[domain.Width]
 	if !ok {
 		return nil, fmt.Errorf("crop option 'width' is required and must be a number")
@@ -34,16 +218,300 @@ func crop(img image.Image, options map[domain.TransformationOptionType]float64)
 		return nil, fmt.Errorf("crop option 'height' is required and must be a number")
 	}
 
-	return imaging.CropCenter(img, int(width), int(height)), nil
+	if x < 0 || y < 0 || width <= 0 || height <= 0 || x+width > 100 || y+height > 100 {
+		return nil, fmt.Errorf("crop percentages must describe an area within 0 and 100 percent of the image")
+	}
+
+	bounds := img.Bounds()
+	rect := image.Rect(
+		int(math.Round(x*float64(bounds.Dx())/100)),
+		int(math.Round(y*float64(bounds.Dy())/100)),
+		int(math.Round((x+width)*float64(bounds.Dx())/100)),
+		int(math.Round((y+height)*float64(bounds.Dy())/100)),
+	)
+	if rect.Empty() {
+		return nil, fmt.Errorf("crop percentages result in an empty image")
+	}
+
+	return imaging.Crop(img, rect.Add(bounds.Min)), nil
+}
+
+// validateRectangle checks that a rectangle given relative to the top-left corner of the image lies within the image.
+func validateRectangle(bounds image.Rectangle, rect image.Rectangle) error {
+	if rect.Dx() < 1 || rect.Dy() < 1 {
+		return fmt.Errorf("rectangle width and height must be positive")
+	}
+
+	if rect.Min.X < 0 || rect.Min.Y < 0 || rect.Max.X > bounds.Dx() || rect.Max.Y > bounds.Dy() {
+		return fmt.Errorf("rectangle %v is outside of the image size %dx%d", rect, bounds.Dx(), bounds.Dy())
+	}
+
+	return nil
 }
 
-func rotate(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+func rotate(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
 	angle, ok := options[domain.Angle]
 	if !ok {
 		return nil, fmt.Errorf("rotate option 'angle' is required and must be a number")
 	}
 
-	return imaging.Rotate(img, angle, color.Transparent), nil
+	// Rotations by multiples of 90 degrees only move pixels around, so they are done without resampling.
+	switch math.Mod(math.Mod(angle, 360)+360, 360) {
+	case 0:
+		return img, nil
+	case 90:
+		return imaging.Rotate90(img), nil
+	case 180:
+		return imaging.Rotate180(img), nil
+	case 270:
+		return imaging.Rotate270(img), nil
+	}
+
+	background, err := parseColor(parameters, domain.Color, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	return imaging.Rotate(img, angle, background), nil
+}
+
+func flipHorizontal(img image.Image) image.Image {
+	return imaging.FlipH(img)
+}
+
+func flipVertical(img image.Image) image.Image {
+	return imaging.FlipV(img)
+}
+
+func transpose(img image.Image) image.Image {
+	return imaging.Transpose(img)
+}
+
+func transverse(img image.Image) image.Image {
+	return imaging.Transverse(img)
+}
+
+func pad(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	background, err := parseColor(parameters, domain.Color, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	// The size option applies to every side, and the per-side options take precedence over it.
+	size := options[domain.Size]
+	top, right, bottom, left := size, size, size, size
+	if v, ok := options[domain.Top]; ok {
+		top = v
+	}
+	if v, ok := options[domain.Right]; ok {
+		right = v
+	}
+	if v, ok := options[domain.Bottom]; ok {
+		bottom = v
+	}
+	if v, ok := options[domain.Left]; ok {
+		left = v
+	}
+
+	if top < 0 || right < 0 || bottom < 0 || left < 0 {
+		return nil, fmt.Errorf("pad options must not be negative")
+	}
+
+	// The sides are checked before they are converted, as too large numbers would wrap around.
+	if max(top, right, bottom, left) > domain.MaxImageDimension {
+		return nil, fmt.Errorf("pad options must not exceed %d", domain.MaxImageDimension)
+	}
+
+	bounds := img.Bounds()
+	if err := validateImageDimensions(bounds.Dx()+int(left)+int(right), bounds.Dy()+int(top)+int(bottom)); err != nil {
+		return nil, err
+	}
+
+	return addMargins(img, int(top), int(right), int(bottom), int(left), background), nil
+}
+
+func border(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	size, ok := options[domain.Size]
+	if !ok || int(size) < 1 {
+		return nil, fmt.Errorf("border option 'size' is required and must be a positive number")
+	}
+	if size > domain.MaxImageDimension {
+		return nil, fmt.Errorf("border option 'size' must not exceed %d", domain.MaxImageDimension)
+	}
+
+	borderColor, err := parseColor(parameters, domain.Color, color.NRGBA{A: 255})
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	if err := validateImageDimensions(bounds.Dx()+2*int(size), bounds.Dy()+2*int(size)); err != nil {
+		return nil, err
+	}
+
+	return addMargins(img, int(size), int(size), int(size), int(size), borderColor), nil
+}
+
+func extendCanvas(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	width, ok := options[domain.Width]
+	if !ok {
+		return nil, fmt.Errorf("extend_canvas option 'width' is required and must be a number")
+	}
+
+	height, ok := options[domain.Height]
+	if !ok {
+		return nil, fmt.Errorf("extend_canvas option 'height' is required and must be a number")
+	}
+
+	bounds := img.Bounds()
+	if int(width) < bounds.Dx() || int(height) < bounds.Dy() {
+		return nil, fmt.Errorf("canvas size %dx%d is smaller than the image size %dx%d", int(width), int(height), bounds.Dx(), bounds.Dy())
+	}
+
+	if err := validateImageDimensions(int(width), int(height)); err != nil {
+		return nil, err
+	}
+
+	anchor, err := parseAnchor(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	background, err := parseColor(parameters, domain.Color, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := imaging.New(int(width), int(height), background)
+
+	return imaging.Paste(canvas, img, anchorPoint(canvas.Bounds(), bounds.Size(), anchor)), nil
+}
+
+func trim(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	tolerance := options[domain.Tolerance]
+	if tolerance < 0 || tolerance > 255 {
+		return nil, fmt.Errorf("trim option 'tolerance' must be between 0 and 255")
+	}
+
+	src := toNRGBA(img)
+
+	// Unless a color is given, the border color is taken from the top-left pixel.
+	borderColor, err := parseColor(parameters, domain.Color, src.NRGBAAt(0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	rect := image.Rectangle{}
+	bounds := src.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if colorDistance(src.NRGBAAt(x, y), borderColor) > uint8(tolerance) {
+				rect = rect.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	// An image consisting only of the border color is left as it is.
+	if rect.Empty() {
+		return src, nil
+	}
+
+	return imaging.Crop(src, rect), nil
+}
+
+// redact obscures the given regions of the image. Transformations overwrite the stored image, so the original pixels
+// of the redacted regions are not kept anywhere and cannot be recovered.
+func redact(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	regions, err := parseRegions(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	for _, region := range regions {
+		err = validateRectangle(bounds, region)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var obscure func(region image.Image) image.Image
+	switch mode := domain.RedactMode(parameters[domain.Mode]); mode {
+	case "", domain.RedactModePixelate:
+		size := optionOrDefault(options, domain.Size, 16)
+		if int(size) < 1 {
+			return nil, fmt.Errorf("redact option 'size' must be a positive number")
+		}
+		obscure = func(region image.Image) image.Image {
+			return pixelateImage(region, int(size))
+		}
+	case domain.RedactModeBlur:
+		sigma := optionOrDefault(options, domain.Sigma, 10)
+		if sigma <= 0 {
+			return nil, fmt.Errorf("redact option 'sigma' must be a positive number")
+		}
+		obscure = func(region image.Image) image.Image {
+			return imaging.Blur(region, sigma)
+		}
+	case domain.RedactModeFill:
+		fillColor, err := parseColor(parameters, domain.Color, color.NRGBA{A: 255})
+		if err != nil {
+			return nil, err
+		}
+		obscure = func(region image.Image) image.Image {
+			return imaging.New(region.Bounds().Dx(), region.Bounds().Dy(), fillColor)
+		}
+	default:
+		return nil, fmt.Errorf("redact mode '%s' is not supported", mode)
+	}
+
+	dst := imaging.Clone(img)
+	for _, region := range regions {
+		dst = imaging.Paste(dst, obscure(imaging.Crop(dst, region)), region.Min)
+	}
+
+	return dst, nil
+}
+
+// pixelateImage replaces every block of the given size with the average color of the block.
+func pixelateImage(img image.Image, blockSize int) image.Image {
+	bounds := img.Bounds()
+	width := max((bounds.Dx()+blockSize-1)/blockSize, 1)
+	height := max((bounds.Dy()+blockSize-1)/blockSize, 1)
+
+	small := imaging.Resize(img, width, height, imaging.Box)
+	return imaging.Resize(small, bounds.Dx(), bounds.Dy(), imaging.NearestNeighbor)
+}
+
+func addMargins(img image.Image, top, right, bottom, left int, background color.Color) image.Image {
+	bounds := img.Bounds()
+	canvas := imaging.New(bounds.Dx()+left+right, bounds.Dy()+top+bottom, background)
+
+	return imaging.Paste(canvas, img, image.Pt(left, top))
 }
 
 func grayscale(img image.Image) image.Image {
@@ -95,6 +563,303 @@ func adjustSaturation(img image.Image, options map[domain.TransformationOptionTy
 	return imaging.AdjustSaturation(img, factor), nil
 }
 
+func adjustGamma(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	gamma, ok := options[domain.Gamma]
+	if !ok || gamma <= 0 {
+		return nil, fmt.Errorf("adjust_gamma option 'gamma' is required and must be a positive number")
+	}
+
+	return imaging.AdjustGamma(img, gamma), nil
+}
+
+func adjustHue(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	angle, ok := options[domain.Angle]
+	if !ok {
+		return nil, fmt.Errorf("adjust_hue option 'angle' is required and must be a number")
+	}
+
+	shift := angle / 360
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		h, s, l := rgbToHSL(c)
+		h += shift
+		h -= math.Floor(h)
+		return hslToRGB(h, s, l, c.A)
+	}), nil
+}
+
+func adjustLevels(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	blackPoint := optionOrDefault(options, domain.BlackPoint, 0)
+	whitePoint := optionOrDefault(options, domain.WhitePoint, 255)
+	outputBlack := optionOrDefault(options, domain.OutputBlack, 0)
+	outputWhite := optionOrDefault(options, domain.OutputWhite, 255)
+	gamma := optionOrDefault(options, domain.Gamma, 1)
+
+	if blackPoint < 0 || whitePoint > 255 || blackPoint >= whitePoint {
+		return nil, fmt.Errorf("adjust_levels options 'black_point' and 'white_point' must satisfy 0 <= black_point < white_point <= 255")
+	}
+	if outputBlack < 0 || outputBlack > 255 || outputWhite < 0 || outputWhite > 255 {
+		return nil, fmt.Errorf("adjust_levels options 'output_black' and 'output_white' must be between 0 and 255")
+	}
+	if gamma <= 0 {
+		return nil, fmt.Errorf("adjust_levels option 'gamma' must be a positive number")
+	}
+
+	channel, err := parseChannel(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	var lut [256]uint8
+	for i := range lut {
+		t := math.Max(0, math.Min(1, (float64(i)-blackPoint)/(whitePoint-blackPoint)))
+		lut[i] = clamp(outputBlack + math.Pow(t, 1/gamma)*(outputWhite-outputBlack))
+	}
+
+	return applyLUT(img, channel, lut), nil
+}
+
+func adjustCurves(
+	img image.Image,
+	parameters map[domain.TransformationParameterType]string,
+	values map[domain.TransformationValuesType][]float64,
+) (image.Image, error) {
+	numbers, err := parseValues(values, domain.Points)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(numbers)%2 != 0 || len(numbers) < 4 {
+		return nil, fmt.Errorf("adjust_curves values 'points' must contain at least two input,output pairs")
+	}
+
+	xs := make([]float64, 0, len(numbers)/2)
+	ys := make([]float64, 0, len(numbers)/2)
+	for i := 0; i < len(numbers); i += 2 {
+		if numbers[i] < 0 || numbers[i] > 255 || numbers[i+1] < 0 || numbers[i+1] > 255 {
+			return nil, fmt.Errorf("adjust_curves points must be between 0 and 255")
+		}
+		if len(xs) > 0 && numbers[i] <= xs[len(xs)-1] {
+			return nil, fmt.Errorf("adjust_curves points must be ordered by strictly increasing input values")
+		}
+		xs = append(xs, numbers[i])
+		ys = append(ys, numbers[i+1])
+	}
+
+	channel, err := parseChannel(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyLUT(img, channel, curveLUT(xs, ys)), nil
+}
+
+func threshold(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	level := optionOrDefault(options, domain.Level, 128)
+	if level < 0 || level > 255 {
+		return nil, fmt.Errorf("threshold option 'level' must be between 0 and 255")
+	}
+
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		if luminance(c) < level {
+			return color.NRGBA{A: c.A}
+		}
+		return color.NRGBA{R: 255, G: 255, B: 255, A: c.A}
+	}), nil
+}
+
+func posterize(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	levels, ok := options[domain.Levels]
+	if !ok || int(levels) < 2 || int(levels) > 256 {
+		return nil, fmt.Errorf("posterize option 'levels' is required and must be between 2 and 256")
+	}
+
+	return applyLUT(img, domain.ChannelAll, posterizeLUT(int(levels))), nil
+}
+
+func tint(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	if _, ok := parameters[domain.Color]; !ok {
+		return nil, fmt.Errorf("tint parameter 'color' is required")
+	}
+
+	tintColor, err := parseColor(parameters, domain.Color, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	strength := optionOrDefault(options, domain.Strength, 0.5)
+	if strength < 0 || strength > 1 {
+		return nil, fmt.Errorf("tint option 'strength' must be between 0 and 1")
+	}
+
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		return mix(c, color.NRGBA{R: tintColor.R, G: tintColor.G, B: tintColor.B, A: c.A}, strength)
+	}), nil
+}
+
+func colorize(
+	img image.Image,
+	options map[domain.TransformationOptionType]float64,
+	parameters map[domain.TransformationParameterType]string,
+) (image.Image, error) {
+	if _, ok := parameters[domain.Color]; !ok {
+		return nil, fmt.Errorf("colorize parameter 'color' is required")
+	}
+
+	colorizeColor, err := parseColor(parameters, domain.Color, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	strength := optionOrDefault(options, domain.Strength, 1)
+	if strength < 0 || strength > 1 {
+		return nil, fmt.Errorf("colorize option 'strength' must be between 0 and 1")
+	}
+
+	// Colorizing keeps the lightness of every pixel and replaces its hue and saturation with the ones of the color.
+	hue, saturation, _ := rgbToHSL(colorizeColor)
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		return mix(c, hslToRGB(hue, saturation, luminance(c)/255, c.A), strength)
+	}), nil
+}
+
+func duotone(img image.Image, parameters map[domain.TransformationParameterType]string) (image.Image, error) {
+	if _, ok := parameters[domain.ShadowColor]; !ok {
+		return nil, fmt.Errorf("duotone parameter 'shadow_color' is required")
+	}
+
+	if _, ok := parameters[domain.HighlightColor]; !ok {
+		return nil, fmt.Errorf("duotone parameter 'highlight_color' is required")
+	}
+
+	shadow, err := parseColor(parameters, domain.ShadowColor, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	highlight, err := parseColor(parameters, domain.HighlightColor, color.NRGBA{})
+	if err != nil {
+		return nil, err
+	}
+
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		out := mix(shadow, highlight, luminance(c)/255)
+		out.A = c.A
+		return out
+	}), nil
+}
+
+func autoEnhance(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	strength, err := parseStrength(domain.AutoEnhance, options)
+	if err != nil {
+		return nil, err
+	}
+
+	h := computeHistogram(img)
+	if h.total == 0 {
+		return img, nil
+	}
+
+	// The darkest and brightest half a percent of the pixels are clipped, so that a few outliers do not prevent the
+	// rest of the image from being stretched to the full range.
+	low := float64(percentile(h.luminance, h.total, 0.005))
+	high := float64(percentile(h.luminance, h.total, 0.995))
+	if high <= low {
+		return img, nil
+	}
+
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = clamp((float64(i) - low) * 255 / (high - low))
+	}
+
+	return applyLUT(img, domain.ChannelAll, blendLUT(lut, strength)), nil
+}
+
+func equalize(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	strength, err := parseStrength(domain.Equalize, options)
+	if err != nil {
+		return nil, err
+	}
+
+	h := computeHistogram(img)
+	if h.total == 0 {
+		return img, nil
+	}
+
+	// The mapping is computed from the luminance histogram and applied to every channel alike, which spreads the
+	// brightness evenly without shifting the hues the way per-channel equalization would.
+	var cdf [256]int
+	sum := 0
+	for i, count := range h.luminance {
+		sum += count
+		cdf[i] = sum
+	}
+
+	cdfMin := 0
+	for _, v := range cdf {
+		if v > 0 {
+			cdfMin = v
+			break
+		}
+	}
+	if h.total == cdfMin {
+		return img, nil
+	}
+
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = clamp(float64(cdf[i]-cdfMin) * 255 / float64(h.total-cdfMin))
+	}
+
+	return applyLUT(img, domain.ChannelAll, blendLUT(lut, strength)), nil
+}
+
+func autoWhiteBalance(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
+	strength, err := parseStrength(domain.AutoWhiteBalance, options)
+	if err != nil {
+		return nil, err
+	}
+
+	h := computeHistogram(img)
+	if h.total == 0 {
+		return img, nil
+	}
+
+	// Gray world assumption: on average, the scene should be neutral gray, so every channel is scaled towards the
+	// common mean.
+	mean := func(counts [256]int) float64 {
+		sum := 0
+		for i, count := range counts {
+			sum += i * count
+		}
+		return float64(sum) / float64(h.total)
+	}
+	red, green, blue := mean(h.red), mean(h.green), mean(h.blue)
+	gray := (red + green + blue) / 3
+
+	gainLUT := func(channelMean float64) [256]uint8 {
+		var lut [256]uint8
+		gain := 1.0
+		if channelMean > 0 {
+			gain = gray / channelMean
+		}
+		for i := range lut {
+			lut[i] = clamp(float64(i) * gain)
+		}
+		return blendLUT(lut, strength)
+	}
+
+	return applyChannelLUTs(img, gainLUT(red), gainLUT(green), gainLUT(blue)), nil
+}
+
 func blur(img image.Image, options map[domain.TransformationOptionType]float64) (image.Image, error) {
 	factor, ok := options[domain.Factor]
 	if !ok {
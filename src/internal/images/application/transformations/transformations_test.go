@@ -0,0 +1,1533 @@
+package transformations
+
+import (
+	"image"
+	"image-processing-service/src/internal/images/domain"
+	"image/color"
+	"testing"
+)
+
+func Test_crop(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    image.Point
+		wantErr bool
+	}{
+		{
+			name: "Center crop by default",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Width: 4, domain.Height: 2},
+			},
+			want:    image.Pt(4, 2),
+			wantErr: false,
+		},
+		{
+			name: "Anchor crop",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 3, domain.Height: 3},
+				parameters: map[domain.TransformationParameterType]string{domain.Anchor: "bottom_right"},
+			},
+			want:    image.Pt(3, 3),
+			wantErr: false,
+		},
+		{
+			name: "Anchor crop larger than image",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Width: 20, domain.Height: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported anchor",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 3, domain.Height: 3},
+				parameters: map[domain.TransformationParameterType]string{domain.Anchor: "middle"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rectangle crop",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{
+					domain.X: 2, domain.Y: 1, domain.Width: 5, domain.Height: 4,
+				},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "rectangle"},
+			},
+			want:    image.Pt(5, 4),
+			wantErr: false,
+		},
+		{
+			name: "Rectangle crop out of bounds",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{
+					domain.X: 8, domain.Y: 0, domain.Width: 5, domain.Height: 4,
+				},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "rectangle"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rectangle crop with negative offset",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{
+					domain.X: -1, domain.Y: 0, domain.Width: 5, domain.Height: 4,
+				},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "rectangle"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Aspect ratio crop",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.AspectWidth: 1, domain.AspectHeight: 1},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "aspect_ratio"},
+			},
+			want:    image.Pt(5, 5),
+			wantErr: false,
+		},
+		{
+			name: "Percentage crop",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{
+					domain.X: 50, domain.Y: 0, domain.Width: 50, domain.Height: 40,
+				},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "percentage"},
+			},
+			want:    image.Pt(5, 2),
+			wantErr: false,
+		},
+		{
+			name: "Percentage crop exceeding image",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{
+					domain.X: 60, domain.Y: 0, domain.Width: 50, domain.Height: 40,
+				},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "percentage"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported mode",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 3, domain.Height: 3},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "circle"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 10, 5))
+			got, err := crop(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("crop() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != tt.want {
+				t.Errorf("crop() got size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_resize(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    image.Point
+		wantErr bool
+	}{
+		{
+			name: "Stretch by default",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Width: 4, domain.Height: 4},
+			},
+			want:    image.Pt(4, 4),
+			wantErr: false,
+		},
+		{
+			name: "Width only",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Width: 20},
+			},
+			want:    image.Pt(20, 10),
+			wantErr: false,
+		},
+		{
+			name: "Height only",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Height: 2},
+				parameters: map[domain.TransformationParameterType]string{domain.Filter: "nearest_neighbor"},
+			},
+			want:    image.Pt(4, 2),
+			wantErr: false,
+		},
+		{
+			name:    "No dimensions",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "Fit",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 6, domain.Height: 6},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "fit"},
+			},
+			want:    image.Pt(6, 3),
+			wantErr: false,
+		},
+		{
+			name: "Fill",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 6, domain.Height: 6},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "fill", domain.Filter: "box"},
+			},
+			want:    image.Pt(6, 6),
+			wantErr: false,
+		},
+		{
+			name: "Pad",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Width: 6, domain.Height: 6},
+				parameters: map[domain.TransformationParameterType]string{
+					domain.Mode:  "pad",
+					domain.Color: "#ffffff",
+				},
+			},
+			want:    image.Pt(6, 6),
+			wantErr: false,
+		},
+		{
+			name: "Pad with invalid color",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 6, domain.Height: 6},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "pad", domain.Color: "white"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported filter",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 6, domain.Height: 6},
+				parameters: map[domain.TransformationParameterType]string{domain.Filter: "bicubic"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Pad beyond maximum size",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Width: 100000, domain.Height: 6},
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "pad"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Proportional height beyond maximum size",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Height: 5000},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 10, 5))
+			got, err := resize(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != tt.want {
+				t.Errorf("resize() got size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_rotate(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    image.Point
+		wantErr bool
+	}{
+		{
+			name:    "Exact rotation",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Angle: 90}},
+			want:    image.Pt(5, 10),
+			wantErr: false,
+		},
+		{
+			name:    "Negative exact rotation",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Angle: -180}},
+			want:    image.Pt(10, 5),
+			wantErr: false,
+		},
+		{
+			name: "Arbitrary rotation with background",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Angle: 45},
+				parameters: map[domain.TransformationParameterType]string{domain.Color: "#ffffff"},
+			},
+			want:    image.Pt(11, 11),
+			wantErr: false,
+		},
+		{
+			name:    "Missing angle",
+			args:    args{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 10, 5))
+			got, err := rotate(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rotate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != tt.want {
+				t.Errorf("rotate() got size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_trim(t *testing.T) {
+	type args struct {
+		img        image.Image
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    image.Point
+		wantErr bool
+	}{
+		{
+			name:    "Trim opaque border",
+			args:    args{img: generateBorderedImage(color.NRGBA{R: 255, G: 255, B: 255, A: 255})},
+			want:    image.Pt(2, 3),
+			wantErr: false,
+		},
+		{
+			name:    "Trim transparent border",
+			args:    args{img: generateBorderedImage(color.NRGBA{})},
+			want:    image.Pt(2, 3),
+			wantErr: false,
+		},
+		{
+			name: "Tolerance covers content",
+			args: args{
+				img:     generateBorderedImage(color.NRGBA{R: 255, G: 255, B: 255, A: 255}),
+				options: map[domain.TransformationOptionType]float64{domain.Tolerance: 255},
+			},
+			want:    image.Pt(10, 10),
+			wantErr: false,
+		},
+		{
+			name: "Invalid tolerance",
+			args: args{
+				img:     generateBorderedImage(color.NRGBA{}),
+				options: map[domain.TransformationOptionType]float64{domain.Tolerance: 300},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := trim(tt.args.img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("trim() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != tt.want {
+				t.Errorf("trim() got size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateImageDimensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		height  int
+		wantErr bool
+	}{
+		{"Smallest size", 1, 1, false},
+		{"Largest size", domain.MaxImageDimension, domain.MaxImageDimension, false},
+		{"Zero width", 0, 10, true},
+		{"Negative height", 10, -1, true},
+		{"Too wide", domain.MaxImageDimension + 1, 10, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateImageDimensions(tt.width, tt.height); (err != nil) != tt.wantErr {
+				t.Errorf("validateImageDimensions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_pad(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    image.Point
+		wantErr bool
+	}{
+		{
+			name: "Pad every side",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Size: 2},
+				parameters: map[domain.TransformationParameterType]string{domain.Color: "#ff000080"},
+			},
+			want:    image.Pt(14, 9),
+			wantErr: false,
+		},
+		{
+			name: "Pad single side",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Size: 2, domain.Left: 0, domain.Right: 5},
+			},
+			want:    image.Pt(15, 9),
+			wantErr: false,
+		},
+		{
+			name: "Negative padding",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Top: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Padding beyond maximum size",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Left: 100000},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Padding too large to convert",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Left: 1e19, domain.Right: 1e19},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 10, 5))
+			got, err := pad(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("pad() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != tt.want {
+				t.Errorf("pad() got size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_extendCanvas(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[domain.TransformationOptionType]float64
+		want    image.Point
+		wantErr bool
+	}{
+		{"Extend canvas", map[domain.TransformationOptionType]float64{domain.Width: 12, domain.Height: 8}, image.Pt(12, 8), false},
+		{"Smaller than image", map[domain.TransformationOptionType]float64{domain.Width: 8, domain.Height: 8}, image.Point{}, true},
+		{"Beyond maximum size", map[domain.TransformationOptionType]float64{domain.Width: 100000, domain.Height: 8}, image.Point{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 10, 5))
+			got, err := extendCanvas(img, tt.options, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("extendCanvas() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != tt.want {
+				t.Errorf("extendCanvas() got size = %v, want %v", got.Bounds().Size(), tt.want)
+			}
+		})
+	}
+}
+
+func generateBorderedImage(borderColor color.NRGBA) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			img.SetNRGBA(x, y, borderColor)
+		}
+	}
+	for y := 3; y < 6; y++ {
+		for x := 4; x < 6; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+		}
+	}
+	return img
+}
+
+func generateUniformImage(c color.NRGBA) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := 0; i < len(img.Pix); i += 4 {
+		copy(img.Pix[i:i+4], []uint8{c.R, c.G, c.B, c.A})
+	}
+	return img
+}
+
+func Test_adjustGamma(t *testing.T) {
+	type args struct {
+		options map[domain.TransformationOptionType]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Brighten",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Gamma: 2}},
+			want:    color.NRGBA{R: 196, G: 160, B: 113, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Darken",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Gamma: 0.5}},
+			want:    color.NRGBA{R: 88, G: 39, B: 10, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Missing gamma",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name:    "Negative gamma",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Gamma: -1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := adjustGamma(img, tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adjustGamma() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("adjustGamma() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_adjustHue(t *testing.T) {
+	type args struct {
+		options map[domain.TransformationOptionType]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Complementary hue",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Angle: 180}},
+			want:    color.NRGBA{R: 50, G: 100, B: 150, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Full turn",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Angle: 360}},
+			want:    color.NRGBA{R: 150, G: 100, B: 50, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Missing angle",
+			args:    args{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := adjustHue(img, tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adjustHue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("adjustHue() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_adjustLevels(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Input range",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.BlackPoint: 50, domain.WhitePoint: 150}},
+			want:    color.NRGBA{R: 255, G: 128, B: 0, A: 255},
+			wantErr: false,
+		},
+		{
+			name: "Single channel",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.BlackPoint: 50, domain.WhitePoint: 150},
+				parameters: map[domain.TransformationParameterType]string{domain.Channel: "red"},
+			},
+			want:    color.NRGBA{R: 255, G: 100, B: 50, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Output range",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.OutputBlack: 100, domain.OutputWhite: 200}},
+			want:    color.NRGBA{R: 159, G: 139, B: 120, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Black point above white point",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.BlackPoint: 200, domain.WhitePoint: 100}},
+			wantErr: true,
+		},
+		{
+			name:    "Output out of range",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.OutputWhite: 300}},
+			wantErr: true,
+		},
+		{
+			name:    "Non-positive gamma",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Gamma: 0}},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported channel",
+			args:    args{parameters: map[domain.TransformationParameterType]string{domain.Channel: "alpha"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := adjustLevels(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adjustLevels() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("adjustLevels() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_adjustCurves(t *testing.T) {
+	type args struct {
+		parameters map[domain.TransformationParameterType]string
+		values     map[domain.TransformationValuesType][]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Identity curve",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Points: {0, 0, 255, 255}}},
+			want:    color.NRGBA{R: 150, G: 100, B: 50, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Inverted curve",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Points: {0, 255, 255, 0}}},
+			want:    color.NRGBA{R: 105, G: 155, B: 205, A: 255},
+			wantErr: false,
+		},
+		{
+			name: "Single channel",
+			args: args{
+				parameters: map[domain.TransformationParameterType]string{domain.Channel: "blue"},
+				values:     map[domain.TransformationValuesType][]float64{domain.Points: {0, 255, 255, 0}},
+			},
+			want:    color.NRGBA{R: 150, G: 100, B: 205, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Missing points",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name:    "Incomplete pair",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Points: {0, 0, 255}}},
+			wantErr: true,
+		},
+		{
+			name:    "Unordered points",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Points: {255, 255, 0, 0}}},
+			wantErr: true,
+		},
+		{
+			name:    "Point out of range",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Points: {0, 0, 300, 255}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := adjustCurves(img, tt.args.parameters, tt.args.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adjustCurves() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("adjustCurves() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_threshold(t *testing.T) {
+	type args struct {
+		options map[domain.TransformationOptionType]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Below default level",
+			args:    args{},
+			want:    color.NRGBA{A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Above level",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Level: 100}},
+			want:    color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Level out of range",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Level: 300}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := threshold(img, tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("threshold() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("threshold() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_posterize(t *testing.T) {
+	type args struct {
+		options map[domain.TransformationOptionType]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Two levels",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Levels: 2}},
+			want:    color.NRGBA{R: 255, G: 0, B: 0, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Four levels",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Levels: 4}},
+			want:    color.NRGBA{R: 170, G: 85, B: 85, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Missing levels",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name:    "Single level",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Levels: 1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := posterize(img, tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("posterize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("posterize() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_tint(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Default strength",
+			args:    args{parameters: map[domain.TransformationParameterType]string{domain.Color: "#0000ff"}},
+			want:    color.NRGBA{R: 75, G: 50, B: 153, A: 255},
+			wantErr: false,
+		},
+		{
+			name: "Full strength",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Strength: 1},
+				parameters: map[domain.TransformationParameterType]string{domain.Color: "#0000ff"},
+			},
+			want:    color.NRGBA{R: 0, G: 0, B: 255, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Missing color",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid color",
+			args:    args{parameters: map[domain.TransformationParameterType]string{domain.Color: "blue"}},
+			wantErr: true,
+		},
+		{
+			name: "Strength out of range",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Strength: 2},
+				parameters: map[domain.TransformationParameterType]string{domain.Color: "#0000ff"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := tint(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("tint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("tint() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_colorize(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Full strength",
+			args:    args{parameters: map[domain.TransformationParameterType]string{domain.Color: "#ff0000"}},
+			want:    color.NRGBA{R: 219, G: 0, B: 0, A: 255},
+			wantErr: false,
+		},
+		{
+			name: "No strength",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Strength: 0},
+				parameters: map[domain.TransformationParameterType]string{domain.Color: "#ff0000"},
+			},
+			want:    color.NRGBA{R: 150, G: 100, B: 50, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Missing color",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "Strength out of range",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Strength: -1},
+				parameters: map[domain.TransformationParameterType]string{domain.Color: "#ff0000"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := colorize(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("colorize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("colorize() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_duotone(t *testing.T) {
+	type args struct {
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name: "Grayscale",
+			args: args{parameters: map[domain.TransformationParameterType]string{
+				domain.ShadowColor: "#000000", domain.HighlightColor: "#ffffff",
+			}},
+			want:    color.NRGBA{R: 109, G: 109, B: 109, A: 255},
+			wantErr: false,
+		},
+		{
+			name: "Blue to red",
+			args: args{parameters: map[domain.TransformationParameterType]string{
+				domain.ShadowColor: "#0000ff", domain.HighlightColor: "#ff0000",
+			}},
+			want:    color.NRGBA{R: 109, G: 0, B: 146, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Missing highlight color",
+			args:    args{parameters: map[domain.TransformationParameterType]string{domain.ShadowColor: "#000000"}},
+			wantErr: true,
+		},
+		{
+			name: "Invalid shadow color",
+			args: args{parameters: map[domain.TransformationParameterType]string{
+				domain.ShadowColor: "black", domain.HighlightColor: "#ffffff",
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateUniformImage(color.NRGBA{R: 150, G: 100, B: 50, A: 255})
+			got, err := duotone(img, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("duotone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("duotone() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_autoWhiteBalance(t *testing.T) {
+	type args struct {
+		options map[domain.TransformationOptionType]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name:    "Full strength",
+			args:    args{},
+			want:    color.NRGBA{R: 100, G: 100, B: 100, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "No strength",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Strength: 0}},
+			want:    color.NRGBA{R: 150, G: 100, B: 50, A: 255},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid strength",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Strength: 2}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+			for i := 0; i < len(img.Pix); i += 4 {
+				copy(img.Pix[i:i+4], []uint8{150, 100, 50, 255})
+			}
+			got, err := autoWhiteBalance(img, tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("autoWhiteBalance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(0, 0) != tt.want {
+				t.Errorf("autoWhiteBalance() got = %v, want %v", toNRGBA(got).NRGBAAt(0, 0), tt.want)
+			}
+		})
+	}
+}
+
+func Test_autoEnhance(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+	img.SetNRGBA(1, 0, color.NRGBA{R: 150, G: 150, B: 150, A: 255})
+
+	got, err := autoEnhance(img, nil)
+	if err != nil {
+		t.Fatalf("autoEnhance() error = %v", err)
+	}
+
+	if dark, light := toNRGBA(got).NRGBAAt(0, 0), toNRGBA(got).NRGBAAt(1, 0); dark.R != 0 || light.R != 255 {
+		t.Errorf("autoEnhance() got = %v, %v, want the full range", dark, light)
+	}
+}
+
+func Test_equalize(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+	img.SetNRGBA(1, 0, color.NRGBA{R: 110, G: 110, B: 110, A: 255})
+	img.SetNRGBA(2, 0, color.NRGBA{R: 120, G: 120, B: 120, A: 255})
+
+	got, err := equalize(img, nil)
+	if err != nil {
+		t.Fatalf("equalize() error = %v", err)
+	}
+
+	dark, mid, light := toNRGBA(got).NRGBAAt(0, 0), toNRGBA(got).NRGBAAt(1, 0), toNRGBA(got).NRGBAAt(2, 0)
+	if dark.R != 0 || mid.R != 128 || light.R != 255 {
+		t.Errorf("equalize() got = %v, %v, %v, want the levels spread evenly", dark, mid, light)
+	}
+
+	got, err = equalize(img, map[domain.TransformationOptionType]float64{domain.Strength: 0})
+	if err != nil {
+		t.Fatalf("equalize() error = %v", err)
+	}
+
+	if c := toNRGBA(got).NRGBAAt(0, 0); c.R != 100 {
+		t.Errorf("equalize() got = %v, want the image unchanged at strength 0", c)
+	}
+
+	if _, err = equalize(img, map[domain.TransformationOptionType]float64{domain.Strength: 2}); err == nil {
+		t.Errorf("equalize() error = nil, want an error for a strength above 1")
+	}
+}
+
+func Test_convolve(t *testing.T) {
+	type args struct {
+		options map[domain.TransformationOptionType]float64
+		values  map[domain.TransformationValuesType][]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    uint8
+		wantErr bool
+	}{
+		{
+			name:    "Identity kernel",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Kernel: {0, 0, 0, 0, 1, 0, 0, 0, 0}}},
+			want:    100,
+			wantErr: false,
+		},
+		{
+			name: "Divisor and bias",
+			args: args{
+				options: map[domain.TransformationOptionType]float64{domain.Divisor: 2, domain.Bias: 10},
+				values:  map[domain.TransformationValuesType][]float64{domain.Kernel: {0, 0, 0, 0, 1, 0, 0, 0, 0}},
+			},
+			want:    60,
+			wantErr: false,
+		},
+		{
+			name:    "Box blur 5x5",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Kernel: {1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}}},
+			want:    100,
+			wantErr: false,
+		},
+		{
+			name:    "Invalid kernel size",
+			args:    args{values: map[domain.TransformationValuesType][]float64{domain.Kernel: {1, 1, 1, 1}}},
+			wantErr: true,
+		},
+		{
+			name:    "Missing kernel",
+			args:    args{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 6, 6))
+			for i := 0; i < len(img.Pix); i += 4 {
+				copy(img.Pix[i:i+4], []uint8{100, 100, 100, 255})
+			}
+			got, err := convolve(img, tt.args.options, tt.args.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("convolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(3, 3).R != tt.want {
+				t.Errorf("convolve() got = %v, want %v", toNRGBA(got).NRGBAAt(3, 3).R, tt.want)
+			}
+		})
+	}
+}
+
+// generateEdgeImage returns a gray 6x6 image whose left half has the first value and the right half the second one.
+func generateEdgeImage(left, right uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 6, 6))
+	for y := range 6 {
+		for x := range 6 {
+			v := left
+			if x >= 3 {
+				v = right
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func Test_edgeDetect(t *testing.T) {
+	type args struct {
+		img        image.Image
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    uint8
+		wantErr bool
+	}{
+		{
+			name:    "Sobel on a flat area",
+			args:    args{img: generateEdgeImage(100, 100)},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "Sobel on an edge",
+			args:    args{img: generateEdgeImage(0, 255)},
+			want:    255,
+			wantErr: false,
+		},
+		{
+			name: "Laplacian on a flat area",
+			args: args{
+				img:        generateEdgeImage(100, 100),
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "laplacian"},
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Laplacian on an edge",
+			args: args{
+				img:        generateEdgeImage(0, 255),
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "laplacian"},
+			},
+			want:    255,
+			wantErr: false,
+		},
+		{
+			name: "Unsupported mode",
+			args: args{
+				img:        generateEdgeImage(0, 255),
+				parameters: map[domain.TransformationParameterType]string{domain.Mode: "canny"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := edgeDetect(tt.args.img, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("edgeDetect() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(2, 3).R != tt.want {
+				t.Errorf("edgeDetect() got = %v, want %v", toNRGBA(got).NRGBAAt(2, 3).R, tt.want)
+			}
+		})
+	}
+}
+
+func Test_emboss(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+		want uint8
+	}{
+		{"Flat area", generateEdgeImage(100, 100), 100},
+		{"Rising edge", generateEdgeImage(0, 255), 255},
+		{"Falling edge", generateEdgeImage(255, 0), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := emboss(tt.img)
+			if toNRGBA(got).NRGBAAt(3, 3).R != tt.want {
+				t.Errorf("emboss() got = %v, want %v", toNRGBA(got).NRGBAAt(3, 3).R, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unsharpMask(t *testing.T) {
+	type args struct {
+		options map[domain.TransformationOptionType]float64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    uint8
+		wantErr bool
+	}{
+		{
+			name:    "Sharpened edge",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Sigma: 1}},
+			want:    85,
+			wantErr: false,
+		},
+		{
+			name:    "Edge above the level",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Sigma: 1, domain.Level: 10}},
+			want:    85,
+			wantErr: false,
+		},
+		{
+			name:    "Edge below the level",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Sigma: 1, domain.Level: 50}},
+			want:    100,
+			wantErr: false,
+		},
+		{
+			name:    "No amount",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Sigma: 1, domain.Amount: 0}},
+			want:    100,
+			wantErr: false,
+		},
+		{
+			name:    "Missing sigma",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name:    "Negative amount",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Sigma: 1, domain.Amount: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "Level out of range",
+			args:    args{options: map[domain.TransformationOptionType]float64{domain.Sigma: 1, domain.Level: 300}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unsharpMask(generateEdgeImage(100, 150), tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unsharpMask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && toNRGBA(got).NRGBAAt(2, 3).R != tt.want {
+				t.Errorf("unsharpMask() got = %v, want %v", toNRGBA(got).NRGBAAt(2, 3).R, tt.want)
+			}
+		})
+	}
+}
+
+func Test_median(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 5, 5))
+	for i := 0; i < len(img.Pix); i += 4 {
+		copy(img.Pix[i:i+4], []uint8{50, 50, 50, 255})
+	}
+	img.SetNRGBA(2, 2, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	got, err := median(img, nil)
+	if err != nil {
+		t.Fatalf("median() error = %v", err)
+	}
+
+	if c := toNRGBA(got).NRGBAAt(2, 2); c.R != 50 {
+		t.Errorf("median() got = %v, want the noise removed", c)
+	}
+}
+
+func Test_validateFilterCost(t *testing.T) {
+	type args struct {
+		bounds image.Rectangle
+		radius int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"Largest image with radius 1", args{image.Rect(0, 0, domain.MaxImageDimension, domain.MaxImageDimension), 1}, false},
+		{"Largest image with radius 2", args{image.Rect(0, 0, domain.MaxImageDimension, domain.MaxImageDimension), 2}, true},
+		{"Small image with largest radius", args{image.Rect(0, 0, 1000, 1000), maxFilterRadius}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFilterCost("median", tt.args.bounds, tt.args.radius); (err != nil) != tt.wantErr {
+				t.Errorf("validateFilterCost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_redact(t *testing.T) {
+	type args struct {
+		options    map[domain.TransformationOptionType]float64
+		parameters map[domain.TransformationParameterType]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    color.NRGBA
+		wantErr bool
+	}{
+		{
+			name: "Fill",
+			args: args{
+				parameters: map[domain.TransformationParameterType]string{
+					domain.Regions: "0,0,4,4",
+					domain.Mode:    "fill",
+					domain.Color:   "#00ff00",
+				},
+			},
+			want:    color.NRGBA{G: 255, A: 255},
+			wantErr: false,
+		},
+		{
+			name: "Pixelate multiple regions",
+			args: args{
+				options:    map[domain.TransformationOptionType]float64{domain.Size: 4},
+				parameters: map[domain.TransformationParameterType]string{domain.Regions: "0,0,4,4;4,4,4,4"},
+			},
+			want:    color.NRGBA{R: 128, G: 128, B: 128, A: 255},
+			wantErr: false,
+		},
+		{
+			name: "Region out of bounds",
+			args: args{
+				parameters: map[domain.TransformationParameterType]string{domain.Regions: "6,6,4,4"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Malformed regions",
+			args: args{
+				parameters: map[domain.TransformationParameterType]string{domain.Regions: "0,0,4"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported mode",
+			args: args{
+				parameters: map[domain.TransformationParameterType]string{domain.Regions: "0,0,4,4", domain.Mode: "erase"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+			for y := 0; y < 8; y++ {
+				for x := 0; x < 8; x++ {
+					v := uint8(255 * ((x + y) % 2))
+					img.SetNRGBA(x, y, color.NRGBA{R: v, G: v, B: v, A: 255})
+				}
+			}
+			got, err := redact(img, tt.args.options, tt.args.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("redact() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && colorDistance(toNRGBA(got).NRGBAAt(1, 1), tt.want) > 1 {
+				t.Errorf("redact() got = %v, want %v", toNRGBA(got).NRGBAAt(1, 1), tt.want)
+			}
+		})
+	}
+}
+
+func Test_effects(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(image.Image) (image.Image, error)
+		wantErr   bool
+	}{
+		{
+			name: "Vignette",
+			transform: func(img image.Image) (image.Image, error) {
+				return vignette(img, map[domain.TransformationOptionType]float64{domain.Strength: 1})
+			},
+			wantErr: false,
+		},
+		{
+			name: "Vignette with invalid radius",
+			transform: func(img image.Image) (image.Image, error) {
+				return vignette(img, map[domain.TransformationOptionType]float64{domain.Radius: 1})
+			},
+			wantErr: true,
+		},
+		{
+			name: "Pixelate",
+			transform: func(img image.Image) (image.Image, error) {
+				return pixelate(img, map[domain.TransformationOptionType]float64{domain.Size: 3})
+			},
+			wantErr: false,
+		},
+		{
+			name: "Pixelate without size",
+			transform: func(img image.Image) (image.Image, error) {
+				return pixelate(img, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Color noise",
+			transform: func(img image.Image) (image.Image, error) {
+				return noise(img, nil, map[domain.TransformationParameterType]string{domain.Mode: "color"})
+			},
+			wantErr: false,
+		},
+		{
+			name: "Pop art",
+			transform: func(img image.Image) (image.Image, error) {
+				return popArt(img, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Oil paint with invalid radius",
+			transform: func(img image.Image) (image.Image, error) {
+				return oilPaint(img, map[domain.TransformationOptionType]float64{domain.Radius: 50})
+			},
+			wantErr: true,
+		},
+		{
+			name: "Oil paint",
+			transform: func(img image.Image) (image.Image, error) {
+				return oilPaint(img, nil)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := generateBorderedImage(color.NRGBA{R: 200, G: 150, B: 100, A: 255})
+			got, err := tt.transform(img)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Bounds().Size() != img.Bounds().Size() {
+				t.Errorf("%s got size = %v, want %v", tt.name, got.Bounds().Size(), img.Bounds().Size())
+			}
+		})
+	}
+}
+
+func Test_removeBackgroundColor(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	img.SetNRGBA(1, 0, color.NRGBA{R: 230, G: 230, B: 230, A: 255})
+	img.SetNRGBA(2, 0, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+
+	packet := &transformationPacket{
+		img:    img,
+		format: "jpeg",
+		transformations: []domain.Transformation{
+			{
+				Type:       domain.RemoveBackground,
+				Options:    map[domain.TransformationOptionType]float64{domain.Tolerance: 10, domain.Feather: 50},
+				Parameters: map[domain.TransformationParameterType]string{domain.Color: "#ffffff"},
+			},
+		},
+	}
+
+	err := applyTransformations(packet)
+	if err != nil {
+		t.Fatalf("applyTransformations() error = %v", err)
+	}
+
+	if packet.format != "png" {
+		t.Errorf("applyTransformations() format = %v, want png", packet.format)
+	}
+
+	result := toNRGBA(packet.img)
+	if a := result.NRGBAAt(0, 0).A; a != 0 {
+		t.Errorf("key color alpha = %v, want 0", a)
+	}
+	if a := result.NRGBAAt(1, 0).A; a == 0 || a == 255 {
+		t.Errorf("feathered color alpha = %v, want partial transparency", a)
+	}
+	if a := result.NRGBAAt(2, 0).A; a != 255 {
+		t.Errorf("distant color alpha = %v, want 255", a)
+	}
+}
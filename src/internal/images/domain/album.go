@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"time"
+)
+
+const MaxAlbumImages = 1000
+
+// Album is a named collection of images of a user in an order of the user's choice. An image can be in any number of
+// albums, and the images stay when an album is deleted.
+type Album struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	// CoverImageID is the image chosen as the cover, which is not valid if none was chosen.
+	CoverImageID uuid.NullUUID
+	// Cover is the name of the cover image, which is the first image of the album unless one was chosen, and empty for
+	// an empty album.
+	Cover      string
+	ImageCount int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewAlbum(userID uuid.UUID, name, description string) *Album {
+	return &Album{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// ValidateAlbumName checks the name of an album, which unlike the names of images is meant to be read and may contain
+// spaces.
+func ValidateAlbumName(name string) error {
+	if len(name) < 1 || len(name) > 128 {
+		return fmt.Errorf("name must be between 1 and 128 characters")
+	}
+
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf("name cannot start or end with spaces")
+	}
+
+	return nil
+}
+
+func DetermineAlbumToUpdate(existingAlbum *Album, newName, newDescription, newCover string) (string, string, error) {
+	if newName == "" && newDescription == "" && newCover == "" {
+		return "", "", fmt.Errorf("no fields to update")
+	}
+
+	if newName == "" {
+		newName = existingAlbum.Name
+	}
+
+	if newDescription == "" {
+		newDescription = existingAlbum.Description
+	}
+
+	return newName, newDescription, nil
+}
+
+// ValidateAlbumImages checks the names of the images added to or removed from an album.
+func ValidateAlbumImages(imageNames []string) error {
+	if len(imageNames) < 1 || len(imageNames) > MaxImagesPerRequest {
+		return fmt.Errorf("between 1 and %d images must be given", MaxImagesPerRequest)
+	}
+
+	for i, imageName := range imageNames {
+		if slices.Contains(imageNames[:i], imageName) {
+			return fmt.Errorf("image '%s' is given more than once", imageName)
+		}
+	}
+
+	return nil
+}
+
+// NewAlbumImageIDs returns the IDs of the images that are not in the album yet, keeping their order. Images given more
+// than once are only returned the first time.
+func NewAlbumImageIDs(images []*ImageMetadata, imageIDs []uuid.UUID) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, id := range imageIDs {
+		inAlbum := slices.ContainsFunc(images, func(image *ImageMetadata) bool {
+			return image.ID == id
+		})
+		if !inAlbum && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// OrderAlbumImages returns the IDs of the images of the album in the order of the given names, which have to name
+// every image of the album exactly once.
+func OrderAlbumImages(images []*ImageMetadata, imageNames []string) ([]uuid.UUID, error) {
+	if len(imageNames) != len(images) {
+		return nil, fmt.Errorf("all %d images of the album must be given", len(images))
+	}
+
+	ids := make([]uuid.UUID, len(imageNames))
+	for i, imageName := range imageNames {
+		path := NormalizeImagePath(imageName)
+		j := slices.IndexFunc(images, func(image *ImageMetadata) bool {
+			return image.Path() == path
+		})
+		if j == -1 {
+			return nil, fmt.Errorf("image '%s' is not in the album", imageName)
+		}
+		if slices.Contains(ids[:i], images[j].ID) {
+			return nil, fmt.Errorf("image '%s' is given more than once", imageName)
+		}
+		ids[i] = images[j].ID
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+)
+
+func TestValidateAlbumName(t *testing.T) {
+	type args struct {
+		name string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Name with spaces",
+			args{name: "Summer 2026"},
+			false,
+		},
+		{
+			"Empty",
+			args{name: ""},
+			true,
+		},
+		{
+			"Leading space",
+			args{name: " Summer"},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAlbumName(tt.args.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAlbumName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewAlbumImageIDs(t *testing.T) {
+	images := []*ImageMetadata{
+		{ID: uuid.New(), Name: "beach.png"},
+		{ID: uuid.New(), Name: "sea.png"},
+	}
+	sky, sun := uuid.New(), uuid.New()
+
+	type args struct {
+		imageIDs []uuid.UUID
+	}
+	tests := []struct {
+		name string
+		args args
+		want []uuid.UUID
+	}{
+		{
+			"New images",
+			args{imageIDs: []uuid.UUID{sun, sky}},
+			[]uuid.UUID{sun, sky},
+		},
+		{
+			"Images already in the album",
+			args{imageIDs: []uuid.UUID{images[1].ID, sky, images[0].ID}},
+			[]uuid.UUID{sky},
+		},
+		{
+			"Same image twice",
+			args{imageIDs: []uuid.UUID{sky, sky}},
+			[]uuid.UUID{sky},
+		},
+		{
+			"Only images already in the album",
+			args{imageIDs: []uuid.UUID{images[0].ID}},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAlbumImageIDs(images, tt.args.imageIDs); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("NewAlbumImageIDs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderAlbumImages(t *testing.T) {
+	images := []*ImageMetadata{
+		{ID: uuid.New(), Name: "beach.png"},
+		{ID: uuid.New(), Name: "sea.png"},
+		{ID: uuid.New(), Name: "sky.png"},
+	}
+
+	type args struct {
+		imageNames []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []uuid.UUID
+		wantErr bool
+	}{
+		{
+			"New order",
+			args{imageNames: []string{"sky.png", "beach.png", "sea.png"}},
+			[]uuid.UUID{images[2].ID, images[0].ID, images[1].ID},
+			false,
+		},
+		{
+			"Missing image",
+			args{imageNames: []string{"sky.png", "beach.png"}},
+			nil,
+			true,
+		},
+		{
+			"Duplicate image",
+			args{imageNames: []string{"sky.png", "sky.png", "sea.png"}},
+			nil,
+			true,
+		},
+		{
+			"Image not in the album",
+			args{imageNames: []string{"sky.png", "beach.png", "sun.png"}},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OrderAlbumImages(images, tt.args.imageNames)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OrderAlbumImages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("OrderAlbumImages() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// ImageAnalysis holds statistics describing the content and the quality of an image. Analysing an image takes a while,
+// so the analysis is stored and reused until the content of the image changes.
+type ImageAnalysis struct {
+	Width      int        `json:"width"`
+	Height     int        `json:"height"`
+	Histograms Histograms `json:"histograms"`
+	// Luminance is measured from 0 to 255.
+	LuminanceMean   float64 `json:"luminance_mean"`
+	LuminanceStdDev float64 `json:"luminance_std_dev"`
+	// Sharpness is the variance of the Laplacian of the luminance, which is low for blurry images.
+	Sharpness float64 `json:"sharpness"`
+	// Noise is the estimated standard deviation of the noise in the luminance.
+	Noise float64 `json:"noise"`
+	// ClippedShadows and ClippedHighlights are the percentages of pixels that are pure black or pure white.
+	ClippedShadows    float64 `json:"clipped_shadows"`
+	ClippedHighlights float64 `json:"clipped_highlights"`
+	UsesAlpha         bool    `json:"uses_alpha"`
+	// JPEGQuality is estimated from the quantization tables, so it is only known for JPEG images.
+	JPEGQuality *int `json:"jpeg_quality"`
+}
+
+// Histograms count the pixels for every value of every channel.
+type Histograms struct {
+	Red       [256]int `json:"red"`
+	Green     [256]int `json:"green"`
+	Blue      [256]int `json:"blue"`
+	Alpha     [256]int `json:"alpha"`
+	Luminance [256]int `json:"luminance"`
+}
+
+func (a ImageAnalysis) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+func (a *ImageAnalysis) Scan(src any) error {
+	data, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into an image analysis", src)
+	}
+
+	return json.Unmarshal(data, a)
+}
@@ -0,0 +1,104 @@
+package domain
+
+import "fmt"
+
+const MaxCompositionImages = 16
+
+type Composition struct {
+	Layout     CompositionLayout
+	Columns    int
+	Spacing    int
+	Width      int
+	Height     int
+	Background string
+	Layers     []CompositionLayer
+}
+
+// CompositionLayer is a single source image of a composition. The position, opacity and blend mode are only used by
+// the layers layout; the other layouts arrange the images on their own.
+type CompositionLayer struct {
+	Name      string
+	X         int
+	Y         int
+	Opacity   float64
+	BlendMode BlendMode
+}
+
+type CompositionLayout string
+
+const (
+	CompositionLayoutGrid       CompositionLayout = "grid"
+	CompositionLayoutHorizontal CompositionLayout = "horizontal"
+	CompositionLayoutVertical   CompositionLayout = "vertical"
+	CompositionLayoutLayers     CompositionLayout = "layers"
+)
+
+type BlendMode string
+
+const (
+	BlendModeNormal     BlendMode = "normal"
+	BlendModeMultiply   BlendMode = "multiply"
+	BlendModeScreen     BlendMode = "screen"
+	BlendModeOverlay    BlendMode = "overlay"
+	BlendModeDarken     BlendMode = "darken"
+	BlendModeLighten    BlendMode = "lighten"
+	BlendModeDifference BlendMode = "difference"
+)
+
+func ValidateComposition(composition Composition) error {
+	switch composition.Layout {
+	case CompositionLayoutGrid, CompositionLayoutHorizontal, CompositionLayoutVertical, CompositionLayoutLayers:
+	default:
+		return fmt.Errorf("layout '%s' is not supported", composition.Layout)
+	}
+
+	if len(composition.Layers) < 1 || len(composition.Layers) > MaxCompositionImages {
+		return fmt.Errorf("composition must contain between 1 and %d images", MaxCompositionImages)
+	}
+
+	if composition.Columns < 0 || composition.Spacing < 0 || composition.Width < 0 || composition.Height < 0 {
+		return fmt.Errorf("columns, spacing, width and height cannot be negative")
+	}
+
+	for _, layer := range composition.Layers {
+		if layer.Opacity < 0 || layer.Opacity > 1 {
+			return fmt.Errorf("opacity of image %s must be between 0 and 1", layer.Name)
+		}
+
+		switch layer.BlendMode {
+		case "", BlendModeNormal, BlendModeMultiply, BlendModeScreen, BlendModeOverlay, BlendModeDarken,
+			BlendModeLighten, BlendModeDifference:
+		default:
+			return fmt.Errorf("blend mode '%s' of image %s is not supported", layer.BlendMode, layer.Name)
+		}
+	}
+
+	return nil
+}
+
+const MaxSheetImages = 64
+
+// SheetFrame is the position of a single image within a sprite or contact sheet.
+type SheetFrame struct {
+	Name   string `json:"name"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// SheetDocument is the coordinate map stored alongside a generated sprite or contact sheet.
+type SheetDocument struct {
+	Image  string       `json:"image"`
+	Width  int          `json:"width"`
+	Height int          `json:"height"`
+	Frames []SheetFrame `json:"frames"`
+}
+
+func ValidateSheetImages(names []string) error {
+	if len(names) > MaxSheetImages {
+		return fmt.Errorf("sheet cannot contain more than %d images", MaxSheetImages)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "testing"
+
+func TestValidateComposition(t *testing.T) {
+	type args struct {
+		composition Composition
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Valid composition",
+			args{composition: Composition{
+				Layout: CompositionLayoutLayers,
+				Layers: []CompositionLayer{{Name: "a", Opacity: 1}, {Name: "b", Opacity: 0.5, BlendMode: BlendModeScreen}},
+			}},
+			false,
+		},
+		{
+			"Unsupported layout",
+			args{composition: Composition{Layout: "circle", Layers: []CompositionLayer{{Name: "a"}}}},
+			true,
+		},
+		{
+			"No images",
+			args{composition: Composition{Layout: CompositionLayoutGrid}},
+			true,
+		},
+		{
+			"Too many images",
+			args{composition: Composition{Layout: CompositionLayoutGrid, Layers: make([]CompositionLayer, MaxCompositionImages+1)}},
+			true,
+		},
+		{
+			"Negative spacing",
+			args{composition: Composition{Layout: CompositionLayoutGrid, Spacing: -1, Layers: []CompositionLayer{{Name: "a"}}}},
+			true,
+		},
+		{
+			"Invalid opacity",
+			args{composition: Composition{Layout: CompositionLayoutLayers, Layers: []CompositionLayer{{Name: "a", Opacity: 2}}}},
+			true,
+		},
+		{
+			"Unsupported blend mode",
+			args{composition: Composition{Layout: CompositionLayoutLayers, Layers: []CompositionLayer{{Name: "a", BlendMode: "dodge"}}}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateComposition(tt.args.composition)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateComposition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
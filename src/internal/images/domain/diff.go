@@ -0,0 +1,36 @@
+package domain
+
+import "fmt"
+
+const MaxDiffThreshold = 255
+
+// DiffSource is one of the images of a diff, given either by the name of a stored image or by the image itself. A stored
+// image is compared as it is, or as one of its preview variants if Variant is set, so that two versions of the same
+// image can be compared.
+type DiffSource struct {
+	Name    string
+	Variant string
+	Bytes   []byte
+}
+
+// DiffMetrics describe how much two images differ. Both images are compared at the size of the first one.
+type DiffMetrics struct {
+	Width  int
+	Height int
+	// PSNR is the peak signal-to-noise ratio in decibels. It is infinite for identical images, which is represented by
+	// nil.
+	PSNR *float64
+	// SSIM is the structural similarity of the luminance. It ranges from -1 to 1, and identical images score 1.
+	SSIM              float64
+	ChangedPercentage float64
+}
+
+// ValidateDiffThreshold checks the largest difference of a channel between two pixels that is still not considered a
+// change.
+func ValidateDiffThreshold(threshold int) error {
+	if threshold < 0 || threshold > MaxDiffThreshold {
+		return fmt.Errorf("threshold must be between 0 and %d", MaxDiffThreshold)
+	}
+
+	return nil
+}
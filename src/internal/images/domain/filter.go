@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ImagesFilter narrows down the images of a user. The zero value matches all images.
+type ImagesFilter struct {
+	// Color matches images that have a palette color within ColorDistance of it.
+	Color         *PaletteColor
+	ColorDistance int
+	// Folder matches images directly in the folder, or anywhere below it if Recursive is set. An empty folder matches
+	// images in all folders.
+	Folder    string
+	Recursive bool
+	// Tags matches images that carry any or all of the tags, depending on TagMatch.
+	Tags     []string
+	TagMatch TagMatch
+	// CreatedFrom is inclusive and CreatedTo is exclusive, so that consecutive ranges do not overlap.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Format      string
+	// The dimensions are inclusive bounds in pixels, where zero leaves a bound out.
+	MinWidth  int
+	MaxWidth  int
+	MinHeight int
+	MaxHeight int
+}
+
+func ValidateImagesFilter(filter ImagesFilter) error {
+	if filter.Folder != "" {
+		err := ValidateFolderPath(filter.Folder)
+		if err != nil {
+			return err
+		}
+	}
+
+	if filter.Color != nil {
+		err := ValidateColorDistance(filter.ColorDistance)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(filter.Tags) > 0 {
+		err := ValidateTags(filter.Tags)
+		if err != nil {
+			return err
+		}
+
+		err = ValidateTagMatch(filter.TagMatch)
+		if err != nil {
+			return err
+		}
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return fmt.Errorf("start of the date range must be before its end")
+	}
+
+	switch filter.Format {
+	case "", "jpeg", "png", "gif":
+	default:
+		return fmt.Errorf("format '%s' is not supported", filter.Format)
+	}
+
+	if filter.MinWidth < 0 || filter.MaxWidth < 0 || filter.MinHeight < 0 || filter.MaxHeight < 0 {
+		return fmt.Errorf("dimensions cannot be negative")
+	}
+	if filter.MaxWidth > 0 && filter.MinWidth > filter.MaxWidth || filter.MaxHeight > 0 && filter.MinHeight > filter.MaxHeight {
+		return fmt.Errorf("minimum dimensions cannot exceed maximum dimensions")
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	// RootFolder holds the images that are not in any folder. It always exists and cannot be changed.
+	RootFolder          = "/"
+	maxFolderNameLength = 128
+	maxFolderDepth      = 16
+)
+
+// Folder is a folder of a user addressed by its path, e.g. "/marketing/2026". Folders are nested by their paths, so
+// moving a folder moves its subfolders and images along with it.
+type Folder struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Path   string
+	// ImageCount counts the images directly in the folder, while TotalImageCount includes the images in its subfolders.
+	ImageCount      int
+	TotalImageCount int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func NewFolder(userID uuid.UUID, path string) *Folder {
+	return &Folder{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Path:      path,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// ValidateFolderPath checks a path that starts with a slash and is made of folder names separated by slashes. The root
+// folder is a valid path.
+func ValidateFolderPath(path string) error {
+	if path == RootFolder {
+		return nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path must start with a slash")
+	}
+
+	names := strings.Split(path[1:], "/")
+	if len(names) > maxFolderDepth {
+		return fmt.Errorf("folders cannot be nested more than %d levels deep", maxFolderDepth)
+	}
+
+	for _, name := range names {
+		if len(name) < 1 || len(name) > maxFolderNameLength {
+			return fmt.Errorf("folder names must be between 1 and %d characters", maxFolderNameLength)
+		}
+
+		if name == "." || name == ".." {
+			return fmt.Errorf("folder names cannot be '.' or '..'")
+		}
+
+		if strings.Contains(name, " ") {
+			return fmt.Errorf("folder names cannot contain spaces")
+		}
+	}
+
+	return nil
+}
+
+// SplitImagePath splits the path of an image into its folder and name. A name without any folder refers to an image in
+// the root folder, so images can still be addressed by their names alone.
+func SplitImagePath(path string) (string, string) {
+	i := strings.LastIndex(path, "/")
+	if i == -1 {
+		return RootFolder, path
+	}
+	if i == 0 {
+		return RootFolder, path[1:]
+	}
+
+	return path[:i], path[i+1:]
+}
+
+// NormalizeImagePath turns a path given by the user into the form returned by ImageMetadata.Path, so that the two can
+// be compared.
+func NormalizeImagePath(path string) string {
+	return ImagePath(SplitImagePath(path))
+}
+
+func ImagePath(folder, name string) string {
+	return FolderPrefix(folder) + name
+}
+
+// FolderPrefix returns the prefix shared by the paths of everything inside the folder.
+func FolderPrefix(path string) string {
+	if path == RootFolder {
+		return RootFolder
+	}
+
+	return path + "/"
+}
+
+// FolderAncestors returns the paths of the folder and all folders above it except the root, the outermost first.
+func FolderAncestors(path string) []string {
+	if path == RootFolder {
+		return nil
+	}
+
+	var paths []string
+	for i := 1; i < len(path); i++ {
+		if path[i] == '/' {
+			paths = append(paths, path[:i])
+		}
+	}
+
+	return append(paths, path)
+}
+
+// ValidateFolderMove checks that a folder can be moved or renamed to the new path, which must not be inside the folder
+// itself.
+func ValidateFolderMove(oldPath, newPath string) error {
+	if oldPath == RootFolder || newPath == RootFolder {
+		return fmt.Errorf("root folder cannot be moved")
+	}
+
+	if oldPath == newPath {
+		return fmt.Errorf("folder is already at '%s'", newPath)
+	}
+
+	if strings.HasPrefix(newPath, FolderPrefix(oldPath)) {
+		return fmt.Errorf("folder cannot be moved into itself")
+	}
+
+	return nil
+}
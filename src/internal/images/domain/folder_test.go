@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitImagePath(t *testing.T) {
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantFolder string
+		wantName   string
+	}{
+		{
+			"Name only",
+			args{path: "banner.png"},
+			RootFolder,
+			"banner.png",
+		},
+		{
+			"Root folder",
+			args{path: "/banner.png"},
+			RootFolder,
+			"banner.png",
+		},
+		{
+			"Nested folder",
+			args{path: "/marketing/2026/banner.png"},
+			"/marketing/2026",
+			"banner.png",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folder, name := SplitImagePath(tt.args.path)
+			if folder != tt.wantFolder || name != tt.wantName {
+				t.Fatalf("SplitImagePath() got = %v, %v, want %v, %v", folder, name, tt.wantFolder, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestValidateFolderPath(t *testing.T) {
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Root folder",
+			args{path: "/"},
+			false,
+		},
+		{
+			"Nested folder",
+			args{path: "/marketing/2026"},
+			false,
+		},
+		{
+			"Relative path",
+			args{path: "marketing"},
+			true,
+		},
+		{
+			"Trailing slash",
+			args{path: "/marketing/"},
+			true,
+		},
+		{
+			"Parent folder",
+			args{path: "/marketing/../sales"},
+			true,
+		},
+		{
+			"Space",
+			args{path: "/marketing/summer sale"},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFolderPath(tt.args.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateFolderPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFolderAncestors(t *testing.T) {
+	got := FolderAncestors("/marketing/2026/q1")
+	want := []string{"/marketing", "/marketing/2026", "/marketing/2026/q1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FolderAncestors() got = %v, want %v", got, want)
+	}
+}
+
+func TestValidateFolderMove(t *testing.T) {
+	type args struct {
+		oldPath string
+		newPath string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Rename",
+			args{oldPath: "/marketing/2026", newPath: "/marketing/2027"},
+			false,
+		},
+		{
+			"Move to a folder with a similar name",
+			args{oldPath: "/marketing", newPath: "/marketing-old"},
+			false,
+		},
+		{
+			"Move into itself",
+			args{oldPath: "/marketing", newPath: "/marketing/archive"},
+			true,
+		},
+		{
+			"Root folder",
+			args{oldPath: "/", newPath: "/archive"},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFolderMove(tt.args.oldPath, tt.args.newPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateFolderMove() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,20 +10,39 @@ import (
 
 const MaxImageSize = 10 * 1024 * 1024
 
+// MaxImageDimension limits the width and the height of the images created by the transformations.
+const MaxImageDimension = 8192
+
 type ImageMetadata struct {
 	ID          uuid.UUID
 	UserID      uuid.UUID
 	Name        string
+	Folder      string
 	Description string
+	BlurHash    string
+	LQIP        string
+	Hashes      ImageHashes
+	Palette     Palette
+	Properties  ImageProperties
+	Tags        []string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
-func NewImageMetadata(userID uuid.UUID, name, description string) *ImageMetadata {
+// ImageProperties describe the stored image itself. Size is measured in bytes.
+type ImageProperties struct {
+	Format string
+	Size   int64
+	Width  int
+	Height int
+}
+
+func NewImageMetadata(userID uuid.UUID, folder, name, description string) *ImageMetadata {
 	return &ImageMetadata{
 		ID:          uuid.New(),
 		UserID:      userID,
 		Name:        name,
+		Folder:      folder,
 		Description: description,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -39,9 +58,19 @@ func ValidateName(name string) error {
 		return fmt.Errorf("name cannot contain spaces")
 	}
 
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("name cannot contain slashes")
+	}
+
 	return nil
 }
 
+// Path returns the path the image is addressed by, e.g. "/marketing/2026/banner.png", as names are only unique within
+// a folder.
+func (m *ImageMetadata) Path() string {
+	return ImagePath(m.Folder, m.Name)
+}
+
 func ValidateDescription(description string) error {
 	if len(description) > 1024 {
 		return fmt.Errorf("description cannot exceed 1024 characters")
@@ -56,7 +85,7 @@ func ValidateImage(bytes []byte) error {
 	}
 
 	mimeType := http.DetectContentType(bytes)
-	if mimeType != "image/jpeg" && mimeType != "image/png" {
+	if mimeType != "image/jpeg" && mimeType != "image/png" && mimeType != "image/gif" {
 		return fmt.Errorf("invalid image format: %s", mimeType)
 	}
 
@@ -86,3 +115,16 @@ func CreateFullImageObjectName(id uuid.UUID) string {
 func CreatePreviewImageObjectName(id uuid.UUID) string {
 	return fmt.Sprintf("prev-%s", id)
 }
+
+// ParseImageObjectName returns the ID of the image an object in the storage belongs to. All object names end with the
+// ID of the image, whatever they start with.
+func ParseImageObjectName(name string) (uuid.UUID, bool) {
+	if len(name) < 36 {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(name[len(name)-36:])
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
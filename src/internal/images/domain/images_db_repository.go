@@ -9,11 +9,38 @@ type ImagesDBRepository interface {
 	CreateImageMetadata(
 		ctx context.Context,
 		userID uuid.UUID,
+		folder,
 		name,
 		description string,
 	) (*ImageMetadata, error)
-	GetImageMetadataByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*ImageMetadata, error)
-	GetImagesMetadataByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]*ImageMetadata, int, error)
+	GetImageMetadataByUserIDAndPath(ctx context.Context, userID uuid.UUID, path string) (*ImageMetadata, error)
+	GetImageMetadataByID(ctx context.Context, id uuid.UUID) (*ImageMetadata, error)
+	ListImagesMetadataByUserID(
+		ctx context.Context,
+		userID uuid.UUID,
+		filter ImagesFilter,
+		listing ImagesListing,
+	) ([]*ImageMetadata, int, error)
+	SearchImagesMetadataByUserID(
+		ctx context.Context,
+		userID uuid.UUID,
+		query string,
+		page,
+		limit int,
+	) ([]*SearchResult, int, error)
+	GetImagesMetadataByUserIDAndContentHash(
+		ctx context.Context,
+		userID uuid.UUID,
+		contentHash string,
+	) ([]*ImageMetadata, error)
+	GetImagesMetadataByUserIDAndIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]*ImageMetadata, error)
+	GetImagesHashesByUserID(ctx context.Context, userID uuid.UUID) ([]*HashedImage, error)
+	GetImagesHashesByUserIDAndPHash(
+		ctx context.Context,
+		userID uuid.UUID,
+		pHash Hash,
+		maxDistance int,
+	) ([]*HashedImage, error)
 	GetAllImagesMetadata(ctx context.Context, page, limit int) ([]*ImageMetadata, int, error)
 	UpdateImageMetadataDetails(
 		ctx context.Context,
@@ -22,5 +49,39 @@ type ImagesDBRepository interface {
 		newDescription string,
 	) error
 	UpdateImageMetadataUpdatedAt(ctx context.Context, id uuid.UUID) error
+	UpdateImageMetadataPlaceholders(ctx context.Context, id uuid.UUID, blurHash, lqip string) error
+	UpdateImageMetadataHashes(ctx context.Context, id uuid.UUID, hashes ImageHashes) error
+	UpdateImageMetadataProperties(ctx context.Context, id uuid.UUID, properties ImageProperties) error
+	UpdateImageMetadataPalette(ctx context.Context, id uuid.UUID, palette Palette) error
 	DeleteImageMetadata(ctx context.Context, id uuid.UUID) error
+	CreateJob(ctx context.Context, userID uuid.UUID, jobType JobType, source JobSource) (*Job, error)
+	GetJobByUserIDAndID(ctx context.Context, userID, id uuid.UUID) (*Job, error)
+	GetActiveJobByUserIDAndSource(ctx context.Context, userID uuid.UUID, jobType JobType, source JobSource) (*Job, error)
+	UpdateJob(ctx context.Context, id uuid.UUID, status JobStatus, imageID uuid.NullUUID, errorMessage string) error
+	DeleteJob(ctx context.Context, id uuid.UUID) error
+	GetImageAnalysis(ctx context.Context, imageID uuid.UUID, contentHash string) (*ImageAnalysis, error)
+	SaveImageAnalysis(ctx context.Context, imageID uuid.UUID, contentHash string, analysis *ImageAnalysis) error
+	AddImageTags(ctx context.Context, userID uuid.UUID, imageIDs []uuid.UUID, tags []string) error
+	RemoveImageTags(ctx context.Context, userID uuid.UUID, imageIDs []uuid.UUID, tags []string) error
+	GetTagsByUserID(ctx context.Context, userID uuid.UUID) ([]*TagCount, error)
+	CreateAlbum(ctx context.Context, userID uuid.UUID, name, description string) (*Album, error)
+	GetAlbumByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*Album, error)
+	GetAlbumsByUserID(ctx context.Context, userID uuid.UUID) ([]*Album, error)
+	UpdateAlbumDetails(
+		ctx context.Context,
+		id uuid.UUID,
+		newName,
+		newDescription string,
+		newCoverImageID uuid.NullUUID,
+	) error
+	DeleteAlbum(ctx context.Context, id uuid.UUID) error
+	GetAlbumImagesMetadata(ctx context.Context, albumID uuid.UUID, page, limit int) ([]*ImageMetadata, int, error)
+	AddAlbumImages(ctx context.Context, albumID uuid.UUID, imageIDs []uuid.UUID) error
+	RemoveAlbumImages(ctx context.Context, albumID uuid.UUID, imageIDs []uuid.UUID) error
+	ReorderAlbumImages(ctx context.Context, albumID uuid.UUID, imageIDs []uuid.UUID) error
+	CreateFolder(ctx context.Context, userID uuid.UUID, path string) error
+	GetFolderByUserIDAndPath(ctx context.Context, userID uuid.UUID, path string) (*Folder, error)
+	GetFoldersByUserID(ctx context.Context, userID uuid.UUID, path string, recursive bool) ([]*Folder, error)
+	MoveFolder(ctx context.Context, userID uuid.UUID, oldPath, newPath string) error
+	DeleteFolder(ctx context.Context, userID uuid.UUID, path string) error
 }
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Job is a long-running operation over the images of a user that is executed in the background. Once completed, it
+// points to the image it produced, or to the image it analysed.
+type Job struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Type      JobType
+	Status    JobStatus
+	ImageID   uuid.NullUUID
+	Source    JobSource
+	Error     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// JobSource is the content of the image a job works on, if it works on a single image. It identifies jobs doing the
+// same work, so that the work is not started again while a job is still doing it.
+type JobSource struct {
+	ImageID     uuid.NullUUID
+	ContentHash string
+}
+
+type JobType string
+
+const (
+	JobTypeSpriteSheet  JobType = "sprite_sheet"
+	JobTypeContactSheet JobType = "contact_sheet"
+	JobTypeAnalysis     JobType = "analysis"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+func NewJob(userID uuid.UUID, jobType JobType, source JobSource) *Job {
+	return &Job{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      jobType,
+		Source:    source,
+		Status:    JobStatusPending,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func CreateDocumentObjectName(id uuid.UUID) string {
+	return fmt.Sprintf("doc-%s", id)
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	DefaultListLimit = 25
+	MaxListLimit     = 100
+	// cursorTimeLayout keeps the microseconds, which is the precision of the timestamps in the database.
+	cursorTimeLayout = "2006-01-02 15:04:05.999999"
+)
+
+type ImagesSort string
+
+const (
+	ImagesSortCreatedAt  ImagesSort = "created_at"
+	ImagesSortUpdatedAt  ImagesSort = "updated_at"
+	ImagesSortName       ImagesSort = "name"
+	ImagesSortSize       ImagesSort = "size"
+	ImagesSortDimensions ImagesSort = "dimensions"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAscending  SortOrder = "asc"
+	SortOrderDescending SortOrder = "desc"
+)
+
+// ImagesListing selects a page of images in the given order. Pages are delimited by cursors rather than offsets, so
+// that a page starts where the previous one ended even if images are added or removed in the meantime. Page numbers
+// are still supported for jumping to a page, and the cursors of the returned page lead on from there.
+type ImagesListing struct {
+	Sort   ImagesSort
+	Order  SortOrder
+	Page   int
+	Limit  int
+	Cursor *Cursor
+}
+
+// Cursor points at the last image of a page. A page following the cursor starts right after that image, while a
+// backward cursor selects the page ending right before it. Images with equal sort values are ordered by their ID, so
+// that every image has a distinct position.
+type Cursor struct {
+	Sort     ImagesSort `json:"s"`
+	Order    SortOrder  `json:"o"`
+	Value    string     `json:"v"`
+	ID       uuid.UUID  `json:"i"`
+	Backward bool       `json:"b,omitempty"`
+}
+
+// Encode turns the cursor into an opaque string that can be put into a URL.
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("cursor is malformed")
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, fmt.Errorf("cursor is malformed")
+	}
+
+	return &cursor, nil
+}
+
+func ValidateImagesListing(listing ImagesListing) error {
+	switch listing.Sort {
+	case ImagesSortCreatedAt, ImagesSortUpdatedAt, ImagesSortName, ImagesSortSize, ImagesSortDimensions:
+	default:
+		return fmt.Errorf("sorting by '%s' is not supported", listing.Sort)
+	}
+
+	if listing.Order != SortOrderAscending && listing.Order != SortOrderDescending {
+		return fmt.Errorf("order must be '%s' or '%s'", SortOrderAscending, SortOrderDescending)
+	}
+
+	if listing.Limit < 1 || listing.Limit > MaxListLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxListLimit)
+	}
+
+	if listing.Page < 1 {
+		return fmt.Errorf("page must be at least 1")
+	}
+
+	if listing.Cursor == nil {
+		return nil
+	}
+
+	if listing.Page != 1 {
+		return fmt.Errorf("page cannot be combined with a cursor")
+	}
+
+	if listing.Cursor.Sort != listing.Sort || listing.Cursor.Order != listing.Order {
+		return fmt.Errorf("cursor belongs to a different order")
+	}
+
+	if !validSortValue(listing.Cursor.Value, listing.Sort) {
+		return fmt.Errorf("cursor is malformed")
+	}
+
+	return nil
+}
+
+// validSortValue reports whether the value of a cursor has the form SortValue gives values of the sort, since cursors
+// come from clients and could have been tampered with.
+func validSortValue(value string, sort ImagesSort) bool {
+	switch sort {
+	case ImagesSortCreatedAt, ImagesSortUpdatedAt:
+		_, err := time.Parse(cursorTimeLayout, value)
+		return err == nil
+	case ImagesSortSize, ImagesSortDimensions:
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	default:
+		return utf8.ValidString(value) && !strings.ContainsRune(value, 0)
+	}
+}
+
+// SortValue returns the value the image is sorted by, in the form used by cursors.
+func SortValue(image *ImageMetadata, sort ImagesSort) string {
+	switch sort {
+	case ImagesSortUpdatedAt:
+		return image.UpdatedAt.Format(cursorTimeLayout)
+	case ImagesSortName:
+		return image.Name
+	case ImagesSortSize:
+		return strconv.FormatInt(image.Properties.Size, 10)
+	case ImagesSortDimensions:
+		return strconv.FormatInt(int64(image.Properties.Width)*int64(image.Properties.Height), 10)
+	default:
+		return image.CreatedAt.Format(cursorTimeLayout)
+	}
+}
+
+// PaginateImages turns the images read for a listing into a page along with the cursors of the pages around it. The
+// images are expected in the order they were read in, which is reversed for backward cursors, and there should be one
+// more image than the limit if there are more images in that direction.
+func PaginateImages(images []*ImageMetadata, listing ImagesListing) ([]*ImageMetadata, *Cursor, *Cursor) {
+	more := len(images) > listing.Limit
+	if more {
+		images = images[:listing.Limit]
+	}
+
+	backward := listing.Cursor != nil && listing.Cursor.Backward
+	if backward {
+		images = slices.Clone(images)
+		slices.Reverse(images)
+	}
+
+	if len(images) == 0 {
+		return images, nil, nil
+	}
+
+	hasNext, hasPrev := more, listing.Cursor != nil || listing.Page > 1
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+
+	var next, prev *Cursor
+	if hasNext {
+		next = newCursor(listing, images[len(images)-1], false)
+	}
+	if hasPrev {
+		prev = newCursor(listing, images[0], true)
+	}
+
+	return images, next, prev
+}
+
+func newCursor(listing ImagesListing, image *ImageMetadata, backward bool) *Cursor {
+	return &Cursor{
+		Sort:     listing.Sort,
+		Order:    listing.Order,
+		Value:    SortValue(image, listing.Sort),
+		ID:       image.ID,
+		Backward: backward,
+	}
+}
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	cursor := &Cursor{
+		Sort:     ImagesSortName,
+		Order:    SortOrderAscending,
+		Value:    "beach.png",
+		ID:       uuid.New(),
+		Backward: true,
+	}
+
+	type args struct {
+		value string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Cursor
+		wantErr bool
+	}{
+		{
+			"Encoded cursor",
+			args{value: cursor.Encode()},
+			cursor,
+			false,
+		},
+		{
+			"Not base64",
+			args{value: "not a cursor"},
+			nil,
+			true,
+		},
+		{
+			"Not JSON",
+			args{value: "bm90IGpzb24"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.args.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DecodeCursor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateImagesListing(t *testing.T) {
+	cursor := func(sort ImagesSort, value string) *Cursor {
+		return &Cursor{Sort: sort, Order: SortOrderDescending, Value: value, ID: uuid.New()}
+	}
+
+	type args struct {
+		listing ImagesListing
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Numbered page",
+			args{listing: ImagesListing{Sort: ImagesSortName, Order: SortOrderDescending, Page: 3, Limit: 25}},
+			false,
+		},
+		{
+			"Time cursor",
+			args{listing: ImagesListing{Sort: ImagesSortCreatedAt, Order: SortOrderDescending, Page: 1, Limit: 25, Cursor: cursor(ImagesSortCreatedAt, "2024-05-01 10:20:30.123456")}},
+			false,
+		},
+		{
+			"Size cursor",
+			args{listing: ImagesListing{Sort: ImagesSortSize, Order: SortOrderDescending, Page: 1, Limit: 25, Cursor: cursor(ImagesSortSize, "1024")}},
+			false,
+		},
+		{
+			"Name cursor",
+			args{listing: ImagesListing{Sort: ImagesSortName, Order: SortOrderDescending, Page: 1, Limit: 25, Cursor: cursor(ImagesSortName, "beach.png")}},
+			false,
+		},
+		{
+			"Page zero",
+			args{listing: ImagesListing{Sort: ImagesSortName, Order: SortOrderDescending, Page: 0, Limit: 25}},
+			true,
+		},
+		{
+			"Page with a cursor",
+			args{listing: ImagesListing{Sort: ImagesSortName, Order: SortOrderDescending, Page: 2, Limit: 25, Cursor: cursor(ImagesSortName, "beach.png")}},
+			true,
+		},
+		{
+			"Tampered time cursor",
+			args{listing: ImagesListing{Sort: ImagesSortUpdatedAt, Order: SortOrderDescending, Page: 1, Limit: 25, Cursor: cursor(ImagesSortUpdatedAt, "yesterday")}},
+			true,
+		},
+		{
+			"Tampered dimensions cursor",
+			args{listing: ImagesListing{Sort: ImagesSortDimensions, Order: SortOrderDescending, Page: 1, Limit: 25, Cursor: cursor(ImagesSortDimensions, "1024x768")}},
+			true,
+		},
+		{
+			"Tampered name cursor",
+			args{listing: ImagesListing{Sort: ImagesSortName, Order: SortOrderDescending, Page: 1, Limit: 25, Cursor: cursor(ImagesSortName, "beach\x00.png")}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateImagesListing(tt.args.listing); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateImagesListing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaginateImages(t *testing.T) {
+	images := make([]*ImageMetadata, 4)
+	for i := range images {
+		images[i] = &ImageMetadata{ID: uuid.New(), Name: string(rune('a' + i))}
+	}
+
+	cursor := func(image *ImageMetadata, backward bool) *Cursor {
+		return &Cursor{Sort: ImagesSortName, Order: SortOrderAscending, Value: image.Name, ID: image.ID, Backward: backward}
+	}
+	listing := func(limit int, cursor *Cursor) ImagesListing {
+		return ImagesListing{Sort: ImagesSortName, Order: SortOrderAscending, Page: 1, Limit: limit, Cursor: cursor}
+	}
+
+	type args struct {
+		images  []*ImageMetadata
+		listing ImagesListing
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantPage []*ImageMetadata
+		wantNext *Cursor
+		wantPrev *Cursor
+	}{
+		{
+			"First page with more images",
+			args{images: images[:3], listing: listing(2, nil)},
+			images[:2],
+			cursor(images[1], false),
+			nil,
+		},
+		{
+			"Only page",
+			args{images: images[:2], listing: listing(2, nil)},
+			images[:2],
+			nil,
+			nil,
+		},
+		{
+			"Last page",
+			args{images: images[2:], listing: listing(2, cursor(images[1], false))},
+			images[2:],
+			nil,
+			cursor(images[2], true),
+		},
+		{
+			"Backward to the first page",
+			args{images: []*ImageMetadata{images[1], images[0]}, listing: listing(2, cursor(images[2], true))},
+			images[:2],
+			cursor(images[1], false),
+			nil,
+		},
+		{
+			"Backward with more images",
+			args{images: []*ImageMetadata{images[2], images[1], images[0]}, listing: listing(2, cursor(images[3], true))},
+			images[1:3],
+			cursor(images[2], false),
+			cursor(images[1], true),
+		},
+		{
+			"Numbered page",
+			args{images: images[2:], listing: ImagesListing{Sort: ImagesSortName, Order: SortOrderAscending, Page: 2, Limit: 2}},
+			images[2:],
+			nil,
+			cursor(images[2], true),
+		},
+		{
+			"No images",
+			args{images: nil, listing: listing(2, cursor(images[3], false))},
+			nil,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, next, prev := PaginateImages(tt.args.images, tt.args.listing)
+			if len(page) != len(tt.wantPage) || len(page) > 0 && !reflect.DeepEqual(page, tt.wantPage) {
+				t.Fatalf("PaginateImages() page = %v, want %v", page, tt.wantPage)
+			}
+			if !reflect.DeepEqual(next, tt.wantNext) {
+				t.Fatalf("PaginateImages() next = %v, want %v", next, tt.wantNext)
+			}
+			if !reflect.DeepEqual(prev, tt.wantPrev) {
+				t.Fatalf("PaginateImages() prev = %v, want %v", prev, tt.wantPrev)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	PaletteSize          = 6
+	DefaultColorDistance = 60
+	// maxColorDistance is the distance between black and white.
+	maxColorDistance = 442
+)
+
+// PaletteColor is one of the dominant colors of an image along with the share of the opaque pixels of the image it
+// covers.
+type PaletteColor struct {
+	R          uint8   `json:"r"`
+	G          uint8   `json:"g"`
+	B          uint8   `json:"b"`
+	Proportion float64 `json:"proportion"`
+}
+
+func (c PaletteColor) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Palette holds the dominant colors of an image, the most common first. It is stored as a JSON array.
+type Palette []PaletteColor
+
+func (p Palette) Value() (driver.Value, error) {
+	if p == nil {
+		p = Palette{}
+	}
+
+	return json.Marshal(p)
+}
+
+func (p *Palette) Scan(src any) error {
+	data, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a palette", src)
+	}
+
+	return json.Unmarshal(data, p)
+}
+
+// ParseHexColor reads a color in the #RRGGBB format.
+func ParseHexColor(value string) (PaletteColor, error) {
+	if len(value) != 7 || !strings.HasPrefix(value, "#") {
+		return PaletteColor{}, fmt.Errorf("color must be in the #RRGGBB format")
+	}
+
+	rgb, err := strconv.ParseUint(value[1:], 16, 32)
+	if err != nil {
+		return PaletteColor{}, fmt.Errorf("color must be in the #RRGGBB format")
+	}
+
+	return PaletteColor{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb)}, nil
+}
+
+// ValidateColorDistance checks the distance between two colors, measured as the Euclidean distance of their RGB values.
+func ValidateColorDistance(distance int) error {
+	if distance < 0 || distance > maxColorDistance {
+		return fmt.Errorf("color distance must be between 0 and %d", maxColorDistance)
+	}
+
+	return nil
+}
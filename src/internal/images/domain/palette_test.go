@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestParseHexColor(t *testing.T) {
+	type args struct {
+		value string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    PaletteColor
+		wantErr bool
+	}{
+		{
+			"Lowercase",
+			args{value: "#ff8000"},
+			PaletteColor{R: 255, G: 128},
+			false,
+		},
+		{
+			"Uppercase",
+			args{value: "#00A0FF"},
+			PaletteColor{G: 160, B: 255},
+			false,
+		},
+		{
+			"Missing hash",
+			args{value: "ff8000"},
+			PaletteColor{},
+			true,
+		},
+		{
+			"Short form",
+			args{value: "#f80"},
+			PaletteColor{},
+			true,
+		},
+		{
+			"Invalid digits",
+			args{value: "#gg8000"},
+			PaletteColor{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHexColor(tt.args.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHexColor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseHexColor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxSearchQueryLength = 256
+	maxSearchTerms       = 16
+)
+
+// The database marks the matches of a search in the snippets with these private-use characters rather than with HTML
+// tags, since the text around the matches still has to be escaped. They are removed from the searched text beforehand,
+// so that they can only come from the database.
+const (
+	SnippetMatchStart = "\uE000"
+	SnippetMatchEnd   = "\uE001"
+)
+
+var snippetReplacer = strings.NewReplacer(SnippetMatchStart, "<mark>", SnippetMatchEnd, "</mark>")
+
+// SearchResult is an image found by a full-text search. The snippet is an HTML excerpt of the searched text, i.e. the
+// name, the description and the tags, with the matching words wrapped in <mark> tags.
+type SearchResult struct {
+	Image   *ImageMetadata
+	Rank    float64
+	Snippet string
+}
+
+// ParseSearchQuery turns free text into a text search query that matches images containing all of its words. Every
+// word is matched as a prefix, so that results show up while the user is still typing. Only letters and digits are
+// kept, which also keeps the operators of the query syntax out of the query.
+func ParseSearchQuery(query string) (string, error) {
+	if len(query) > maxSearchQueryLength {
+		return "", fmt.Errorf("query cannot exceed %d characters", maxSearchQueryLength)
+	}
+
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(terms) == 0 {
+		return "", fmt.Errorf("query must contain at least one letter or digit")
+	}
+	if len(terms) > maxSearchTerms {
+		return "", fmt.Errorf("query cannot contain more than %d words", maxSearchTerms)
+	}
+
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+
+	return strings.Join(terms, " & "), nil
+}
+
+// NewSnippet turns a headline whose matches are marked by SnippetMatchStart and SnippetMatchEnd into HTML, escaping the
+// text so that it can be shown as it is.
+func NewSnippet(headline string) string {
+	return snippetReplacer.Replace(html.EscapeString(headline))
+}
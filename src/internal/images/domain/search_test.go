@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	type args struct {
+		query string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Single word",
+			args{query: "Beach"},
+			"beach:*",
+			false,
+		},
+		{
+			"Several words and separators",
+			args{query: "summer-2026 banner.png"},
+			"summer:* & 2026:* & banner:* & png:*",
+			false,
+		},
+		{
+			"Operators are dropped",
+			args{query: "sun & !sea | (sky):*"},
+			"sun:* & sea:* & sky:*",
+			false,
+		},
+		{
+			"Only operators",
+			args{query: "&|!"},
+			"",
+			true,
+		},
+		{
+			"Too long",
+			args{query: strings.Repeat("a", maxSearchQueryLength+1)},
+			"",
+			true,
+		},
+		{
+			"Too many words",
+			args{query: strings.Repeat("a ", maxSearchTerms+1)},
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSearchQuery(tt.args.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSearchQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseSearchQuery() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSnippet(t *testing.T) {
+	type args struct {
+		headline string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Marked match",
+			args{headline: "sunset at the " + SnippetMatchStart + "beach" + SnippetMatchEnd},
+			"sunset at the <mark>beach</mark>",
+		},
+		{
+			"Markup in the text",
+			args{headline: "<script>alert('" + SnippetMatchStart + "x" + SnippetMatchEnd + "')</script> & more"},
+			"&lt;script&gt;alert(&#39;<mark>x</mark>&#39;)&lt;/script&gt; &amp; more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSnippet(tt.args.headline); got != tt.want {
+				t.Fatalf("NewSnippet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
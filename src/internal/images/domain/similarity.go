@@ -0,0 +1,355 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSimilarityDistance is the largest number of differing bits of the perceptual hashes for which two images are
+// still considered near-duplicates.
+const DefaultSimilarityDistance = 10
+
+// Hash is a 64-bit perceptual hash, such as an aHash, dHash or pHash. Similar images have hashes that differ in only a
+// few bits. It is stored as a BIGINT, since PostgreSQL has no unsigned integers.
+type Hash uint64
+
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+func (h Hash) Value() (driver.Value, error) {
+	return int64(h), nil
+}
+
+func (h *Hash) Scan(src any) error {
+	value, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a hash", src)
+	}
+
+	*h = Hash(value)
+	return nil
+}
+
+// ImageHashes identify the content of an image. The content hash matches only identical files, while the perceptual
+// hashes also match images that look alike, e.g. after being resized or recompressed.
+type ImageHashes struct {
+	ContentHash string
+	AHash       Hash
+	DHash       Hash
+	PHash       Hash
+}
+
+// SimilarImage is an image found to be similar to another one, along with the distance between their pHashes.
+type SimilarImage struct {
+	Image    *ImageMetadata
+	Distance int
+	Exact    bool
+}
+
+// HashedImage is an image reduced to its hashes, which is all that is needed to compare it with other images.
+type HashedImage struct {
+	ID     uuid.UUID
+	Hashes ImageHashes
+}
+
+// HashMatch is an image found to be similar to a query, before its metadata is read.
+type HashMatch struct {
+	ID       uuid.UUID
+	Distance int
+	Exact    bool
+}
+
+// PHashSegmentCount is the number of segments the pHash is split into for multi-index hashing. Two pHashes within a
+// distance of each other differ in at most distance/PHashSegmentCount bits in at least one of their segments, so the
+// images near a pHash can be found by looking up the values near each of its segments in an index instead of comparing
+// the pHash with every image.
+const PHashSegmentCount = 4
+
+const (
+	pHashSegmentBits = 64 / PHashSegmentCount
+	// maxPHashSegmentDistance keeps the number of values looked up per segment below a thousand. Larger distances
+	// match a good part of all images anyway, so they are compared with every image instead.
+	maxPHashSegmentDistance = 3
+)
+
+// Segments returns the segments of the hash, starting with its most significant bits.
+func (h Hash) Segments() [PHashSegmentCount]int64 {
+	var segments [PHashSegmentCount]int64
+	for i := range segments {
+		shift := 64 - pHashSegmentBits*(i+1)
+		segments[i] = int64(uint64(h) >> shift & (1<<pHashSegmentBits - 1))
+	}
+
+	return segments
+}
+
+// PHashSegmentCandidates returns, for each segment, the values the segment of a pHash must take for the pHash to
+// possibly be within maxDistance of the hash. An image is a candidate if any of its segments has one of these values.
+// It returns false if there are too many values to look up, in which case every image is a candidate.
+func PHashSegmentCandidates(h Hash, maxDistance int) ([PHashSegmentCount][]int64, bool) {
+	var candidates [PHashSegmentCount][]int64
+	radius := maxDistance / PHashSegmentCount
+	if radius > maxPHashSegmentDistance {
+		return candidates, false
+	}
+
+	for i, segment := range h.Segments() {
+		candidates[i] = segmentNeighbours(segment, radius)
+	}
+
+	return candidates, true
+}
+
+// segmentNeighbours returns the segment along with every value that differs from it in at most radius bits.
+func segmentNeighbours(segment int64, radius int) []int64 {
+	neighbours := []int64{segment}
+
+	var flip func(value int64, from, remaining int)
+	flip = func(value int64, from, remaining int) {
+		for bit := from; bit < pHashSegmentBits; bit++ {
+			flipped := value ^ 1<<bit
+			neighbours = append(neighbours, flipped)
+			if remaining > 1 {
+				flip(flipped, bit+1, remaining-1)
+			}
+		}
+	}
+	if radius > 0 {
+		flip(segment, 0, radius)
+	}
+
+	return neighbours
+}
+
+// FindHashMatches compares the query with the hashes of the images and returns the images within maxDistance, exact
+// duplicates first and then closest first.
+func FindHashMatches(query ImageHashes, images []*HashedImage, maxDistance int) []*HashMatch {
+	var matches []*HashMatch
+	for _, image := range images {
+		distance, exact, ok := compareHashes(query, image.Hashes, maxDistance)
+		if ok {
+			matches = append(matches, &HashMatch{ID: image.ID, Distance: distance, Exact: exact})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b *HashMatch) int {
+		if a.Exact != b.Exact {
+			if a.Exact {
+				return -1
+			}
+			return 1
+		}
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+
+	return matches
+}
+
+// NewSimilarImages joins the matches with the metadata of their images. Matches whose image is missing, e.g. because it
+// has been deleted in the meantime, are left out.
+func NewSimilarImages(matches []*HashMatch, images []*ImageMetadata) []*SimilarImage {
+	imagesByID := make(map[uuid.UUID]*ImageMetadata, len(images))
+	for _, image := range images {
+		imagesByID[image.ID] = image
+	}
+
+	var similar []*SimilarImage
+	for _, match := range matches {
+		image, ok := imagesByID[match.ID]
+		if ok {
+			similar = append(similar, &SimilarImage{Image: image, Distance: match.Distance, Exact: match.Exact})
+		}
+	}
+
+	sortSimilarImages(similar)
+	return similar
+}
+
+const MaxSimilarImagesLimit = 100
+
+func ValidateSimilarImagesLimit(limit int) error {
+	if limit < 1 || limit > MaxSimilarImagesLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxSimilarImagesLimit)
+	}
+
+	return nil
+}
+
+func ValidateSimilarityDistance(distance int) error {
+	if distance < 0 || distance > 64 {
+		return fmt.Errorf("distance must be between 0 and 64")
+	}
+
+	return nil
+}
+
+// compareHashes returns the distance between the pHashes of two images and whether they are exact duplicates. Images
+// are similar if their pHashes are within maxDistance and either their aHashes or their dHashes are too, since the pHash
+// alone now and then matches images that only share their overall structure. Images stored before the hashes were
+// introduced have none, and must not be matched with each other just because their empty hashes are equal.
+func compareHashes(a, b ImageHashes, maxDistance int) (int, bool, bool) {
+	if a.ContentHash == "" || b.ContentHash == "" {
+		return 0, false, false
+	}
+
+	if a.ContentHash == b.ContentHash {
+		return 0, true, true
+	}
+
+	distance := a.PHash.Distance(b.PHash)
+	if distance > maxDistance {
+		return 0, false, false
+	}
+
+	if a.AHash.Distance(b.AHash) > maxDistance && a.DHash.Distance(b.DHash) > maxDistance {
+		return 0, false, false
+	}
+
+	return distance, false, true
+}
+
+// GroupSimilarImages puts the IDs of images that are similar to each other, directly or through other images, into
+// groups, keeping the order of the images. Images that are not similar to any other image are left out. Each image is
+// only compared with the images sharing its content hash or a nearby pHash segment, unless the distance is too large
+// for the segments to narrow down the images.
+func GroupSimilarImages(images []*HashedImage, maxDistance int) [][]uuid.UUID {
+	parents := make([]int, len(images))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	union := func(i, j int) {
+		if find(i) == find(j) {
+			return
+		}
+		if _, _, ok := compareHashes(images[i].Hashes, images[j].Hashes, maxDistance); ok {
+			parents[find(j)] = find(i)
+		}
+	}
+
+	if _, ok := PHashSegmentCandidates(0, maxDistance); ok {
+		contentHashes := make(map[string]int)
+		var segments [PHashSegmentCount]map[int64][]int
+		for i := range segments {
+			segments[i] = make(map[int64][]int)
+		}
+
+		for i, image := range images {
+			if image.Hashes.ContentHash == "" {
+				continue
+			}
+
+			if j, ok := contentHashes[image.Hashes.ContentHash]; ok {
+				union(j, i)
+			} else {
+				contentHashes[image.Hashes.ContentHash] = i
+			}
+
+			candidates, _ := PHashSegmentCandidates(image.Hashes.PHash, maxDistance)
+			for k, values := range candidates {
+				for _, value := range values {
+					for _, j := range segments[k][value] {
+						union(j, i)
+					}
+				}
+			}
+
+			for k, segment := range image.Hashes.PHash.Segments() {
+				segments[k][segment] = append(segments[k][segment], i)
+			}
+		}
+	} else {
+		for i := range images {
+			for j := i + 1; j < len(images); j++ {
+				union(i, j)
+			}
+		}
+	}
+
+	groupIndices := make(map[int]int)
+	var groups [][]uuid.UUID
+	for i, image := range images {
+		root := find(i)
+		index, ok := groupIndices[root]
+		if !ok {
+			index = len(groups)
+			groupIndices[root] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], image.ID)
+	}
+
+	var similarGroups [][]uuid.UUID
+	for _, group := range groups {
+		if len(group) > 1 {
+			similarGroups = append(similarGroups, group)
+		}
+	}
+
+	return similarGroups
+}
+
+// NewImageGroups replaces the IDs in the groups with the metadata of their images. Images that are missing, e.g.
+// because they have been deleted in the meantime, are left out, along with the groups that no longer have duplicates.
+func NewImageGroups(groups [][]uuid.UUID, images []*ImageMetadata) [][]*ImageMetadata {
+	imagesByID := make(map[uuid.UUID]*ImageMetadata, len(images))
+	for _, image := range images {
+		imagesByID[image.ID] = image
+	}
+
+	var imageGroups [][]*ImageMetadata
+	for _, group := range groups {
+		var imageGroup []*ImageMetadata
+		for _, id := range group {
+			image, ok := imagesByID[id]
+			if ok {
+				imageGroup = append(imageGroup, image)
+			}
+		}
+
+		if len(imageGroup) > 1 {
+			imageGroups = append(imageGroups, imageGroup)
+		}
+	}
+
+	return imageGroups
+}
+
+// sortSimilarImages orders the images by distance, putting exact duplicates first and breaking ties by name so that
+// the order does not depend on how the images were found.
+func sortSimilarImages(similar []*SimilarImage) {
+	slices.SortFunc(similar, func(a, b *SimilarImage) int {
+		if a.Exact != b.Exact {
+			if a.Exact {
+				return -1
+			}
+			return 1
+		}
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		return strings.Compare(a.Image.Name, b.Image.Name)
+	})
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func newHashedImage(name, contentHash string, pHash Hash) *ImageMetadata {
+	return &ImageMetadata{ID: uuid.New(), Name: name, Hashes: ImageHashes{ContentHash: contentHash, PHash: pHash}}
+}
+
+func TestHash_Distance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    Hash
+		b    Hash
+		want int
+	}{
+		{"Equal", 0xff, 0xff, 0},
+		{"One bit", 0b1000, 0b0000, 1},
+		{"All bits", 0, ^Hash(0), 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Distance(tt.b); got != tt.want {
+				t.Fatalf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupSimilarImages(t *testing.T) {
+	images := []*HashedImage{
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "a", PHash: 0}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "b", PHash: 0xffffffff00000000}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "c", PHash: 0b1111}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "d", PHash: 0x00000000ffffffff}},
+		// Within the distance of the third image, but not of the first one, so it joins the group through the third.
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "e", PHash: 0b11111111}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "b", PHash: 0xf0f0f0f0f0f0f0f0}},
+		// Only the pHash is close, the aHash and dHash are not.
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "f", AHash: ^Hash(0), DHash: ^Hash(0), PHash: 0b1}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "", PHash: 0}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "", PHash: 0}},
+	}
+
+	// The segments narrow down the images for the smaller distance, while every image is compared for the larger one.
+	for _, maxDistance := range []int{4, 20} {
+		got := GroupSimilarImages(images, maxDistance)
+
+		want := [][]uuid.UUID{{images[0].ID, images[2].ID, images[4].ID}, {images[1].ID, images[5].ID}}
+		if len(got) != len(want) {
+			t.Fatalf("GroupSimilarImages(%d) returned %d groups, want %d", maxDistance, len(got), len(want))
+		}
+		for i := range want {
+			if !slices.Equal(got[i], want[i]) {
+				t.Fatalf("GroupSimilarImages(%d)[%d] = %v, want %v", maxDistance, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestNewImageGroups(t *testing.T) {
+	a1, a2, b1 := newHashedImage("a1", "a", 0), newHashedImage("a2", "a", 0), newHashedImage("b1", "b", 0)
+	groups := [][]uuid.UUID{{a1.ID, a2.ID}, {b1.ID, uuid.New()}}
+
+	got := NewImageGroups(groups, []*ImageMetadata{b1, a2, a1})
+
+	if len(got) != 1 || len(got[0]) != 2 || got[0][0] != a1 || got[0][1] != a2 {
+		t.Fatalf("NewImageGroups() = %v, want the group of a1 and a2", got)
+	}
+}
+
+func TestHash_Segments(t *testing.T) {
+	got := Hash(0x0123456789abcdef).Segments()
+	want := [PHashSegmentCount]int64{0x0123, 0x4567, 0x89ab, 0xcdef}
+	if got != want {
+		t.Fatalf("Segments() = %x, want %x", got, want)
+	}
+}
+
+func TestPHashSegmentCandidates(t *testing.T) {
+	// #nosec G404 -- the hashes only need to be reproducible, not unpredictable
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for _, maxDistance := range []int{0, 3, 5, 10, 15} {
+		query := Hash(random.Uint64())
+		candidates, ok := PHashSegmentCandidates(query, maxDistance)
+		if !ok {
+			t.Fatalf("PHashSegmentCandidates(%d) cannot look up the segments", maxDistance)
+		}
+
+		// Every hash within the distance has to be found through at least one of its segments.
+		for range 1000 {
+			hash := query
+			for range random.IntN(maxDistance + 1) {
+				hash ^= Hash(1) << random.IntN(64)
+			}
+
+			segments := hash.Segments()
+			found := false
+			for i := range segments {
+				if slices.Contains(candidates[i], segments[i]) {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("PHashSegmentCandidates(%d) misses %v at distance %d of %v", maxDistance, hash, hash.Distance(query), query)
+			}
+		}
+	}
+}
+
+func TestPHashSegmentCandidates_Count(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxDistance int
+		want        int
+		wantOK      bool
+	}{
+		{"Exact segment", 3, 1, true},
+		{"One bit", 4, 17, true},
+		{"Three bits", 15, 697, true},
+		{"Too many", 16, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, ok := PHashSegmentCandidates(0x0123456789abcdef, tt.maxDistance)
+			if ok != tt.wantOK {
+				t.Fatalf("PHashSegmentCandidates() ok = %v, want %v", ok, tt.wantOK)
+			}
+			for i, values := range candidates {
+				if len(values) != tt.want {
+					t.Fatalf("PHashSegmentCandidates()[%d] has %d values, want %d", i, len(values), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFindHashMatches(t *testing.T) {
+	images := []*HashedImage{
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "b", PHash: 0b1}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "c", PHash: 0b111}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "a", PHash: 0b11}},
+		{ID: uuid.New(), Hashes: ImageHashes{ContentHash: "", PHash: 0}},
+	}
+
+	got := FindHashMatches(ImageHashes{ContentHash: "a", PHash: 0}, images, 2)
+
+	want := []*HashMatch{
+		{ID: images[2].ID, Distance: 0, Exact: true},
+		{ID: images[0].ID, Distance: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FindHashMatches() returned %d images, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Fatalf("FindHashMatches()[%d] = %+v, want %+v", i, *got[i], *want[i])
+		}
+	}
+}
+
+func TestNewSimilarImages(t *testing.T) {
+	b := newHashedImage("b", "b", 0)
+	a := newHashedImage("a", "a", 0)
+	matches := []*HashMatch{{ID: b.ID, Distance: 1}, {ID: a.ID, Distance: 1}, {ID: uuid.New(), Distance: 0}}
+
+	got := NewSimilarImages(matches, []*ImageMetadata{a, b})
+
+	if len(got) != 2 || got[0].Image != a || got[1].Image != b {
+		t.Fatalf("NewSimilarImages() = %v, want the images a and b", got)
+	}
+}
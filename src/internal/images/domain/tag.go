@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	MaxTagLength        = 64
+	MaxTagsPerRequest   = 32
+	MaxImagesPerRequest = 100
+)
+
+// TagCount is a tag of a user along with the number of images carrying it.
+type TagCount struct {
+	Name  string
+	Count int
+}
+
+// TagMatch decides whether a filtered image needs to carry any or all of the requested tags.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+// NormalizeTags trims and lowercases the tags and drops duplicates, so that "Beach" and "beach " are the same tag.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
+// ValidateTags checks tags that have already been normalized.
+func ValidateTags(tags []string) error {
+	if len(tags) < 1 || len(tags) > MaxTagsPerRequest {
+		return fmt.Errorf("between 1 and %d tags must be given", MaxTagsPerRequest)
+	}
+
+	for _, tag := range tags {
+		if tag == "" || len(tag) > MaxTagLength {
+			return fmt.Errorf("tag '%s' must be between 1 and %d characters", tag, MaxTagLength)
+		}
+
+		for _, r := range tag {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+				return fmt.Errorf("tag '%s' can only contain lowercase letters, digits, dashes and underscores", tag)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ValidateTagMatch(match TagMatch) error {
+	switch match {
+	case TagMatchAny, TagMatchAll:
+		return nil
+	default:
+		return fmt.Errorf("tag match '%s' is not supported", match)
+	}
+}
+
+// ValidateTaggedImages checks the names of the images tagged in bulk.
+func ValidateTaggedImages(imageNames []string) error {
+	if len(imageNames) < 1 || len(imageNames) > MaxImagesPerRequest {
+		return fmt.Errorf("between 1 and %d images must be given", MaxImagesPerRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{"Beach", " beach ", "summer-2026", "SUMMER-2026", "sea"})
+	want := []string{"beach", "summer-2026", "sea"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeTags() got = %v, want %v", got, want)
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	type args struct {
+		tags []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Valid tags",
+			args{tags: []string{"beach", "summer-2026", "road_trip"}},
+			false,
+		},
+		{
+			"No tags",
+			args{tags: []string{}},
+			true,
+		},
+		{
+			"Empty tag",
+			args{tags: []string{""}},
+			true,
+		},
+		{
+			"Too long",
+			args{tags: []string{strings.Repeat("a", MaxTagLength+1)}},
+			true,
+		},
+		{
+			"Space",
+			args{tags: []string{"road trip"}},
+			true,
+		},
+		{
+			"Uppercase",
+			args{tags: []string{"Beach"}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTags(tt.args.tags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
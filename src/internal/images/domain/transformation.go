@@ -1,8 +1,10 @@
 package domain
 
 type Transformation struct {
-	Type    TransformationType
-	Options map[TransformationOptionType]float64
+	Type       TransformationType
+	Options    map[TransformationOptionType]float64
+	Parameters map[TransformationParameterType]string
+	Values     map[TransformationValuesType][]float64
 }
 
 type TransformationType string
@@ -19,13 +21,173 @@ const (
 	AdjustSaturation TransformationType = "adjust_saturation"
 	Blur             TransformationType = "blur"
 	Sharpen          TransformationType = "sharpen"
+	FlipHorizontal   TransformationType = "flip_horizontal"
+	FlipVertical     TransformationType = "flip_vertical"
+	Transpose        TransformationType = "transpose"
+	Transverse       TransformationType = "transverse"
+	Pad              TransformationType = "pad"
+	Border           TransformationType = "border"
+	ExtendCanvas     TransformationType = "extend_canvas"
+	Trim             TransformationType = "trim"
+	AdjustGamma      TransformationType = "adjust_gamma"
+	AdjustHue        TransformationType = "adjust_hue"
+	AdjustLevels     TransformationType = "adjust_levels"
+	AdjustCurves     TransformationType = "adjust_curves"
+	Threshold        TransformationType = "threshold"
+	Posterize        TransformationType = "posterize"
+	Tint             TransformationType = "tint"
+	Colorize         TransformationType = "colorize"
+	Duotone          TransformationType = "duotone"
+	AutoEnhance      TransformationType = "auto_enhance"
+	Equalize         TransformationType = "equalize"
+	AutoWhiteBalance TransformationType = "auto_white_balance"
+	Convolve         TransformationType = "convolve"
+	EdgeDetect       TransformationType = "edge_detect"
+	Emboss           TransformationType = "emboss"
+	Median           TransformationType = "median"
+	UnsharpMask      TransformationType = "unsharp_mask"
+	Redact           TransformationType = "redact"
+	Vignette         TransformationType = "vignette"
+	Pixelate         TransformationType = "pixelate"
+	Noise            TransformationType = "noise"
+	PopArt           TransformationType = "pop_art"
+	OilPaint         TransformationType = "oil_paint"
+	RemoveBackground TransformationType = "remove_background_color"
+	ExtractFrame     TransformationType = "extract_frame"
+	ChangeSpeed      TransformationType = "change_speed"
+	TrimFrames       TransformationType = "trim_frames"
 )
 
 type TransformationOptionType string
 
 const (
-	Width  TransformationOptionType = "width"
-	Height TransformationOptionType = "height"
-	Angle  TransformationOptionType = "angle"
-	Factor TransformationOptionType = "factor"
+	Width        TransformationOptionType = "width"
+	Height       TransformationOptionType = "height"
+	Angle        TransformationOptionType = "angle"
+	Factor       TransformationOptionType = "factor"
+	X            TransformationOptionType = "x"
+	Y            TransformationOptionType = "y"
+	AspectWidth  TransformationOptionType = "aspect_width"
+	AspectHeight TransformationOptionType = "aspect_height"
+	Size         TransformationOptionType = "size"
+	Top          TransformationOptionType = "top"
+	Right        TransformationOptionType = "right"
+	Bottom       TransformationOptionType = "bottom"
+	Left         TransformationOptionType = "left"
+	Tolerance    TransformationOptionType = "tolerance"
+	Gamma        TransformationOptionType = "gamma"
+	BlackPoint   TransformationOptionType = "black_point"
+	WhitePoint   TransformationOptionType = "white_point"
+	OutputBlack  TransformationOptionType = "output_black"
+	OutputWhite  TransformationOptionType = "output_white"
+	Level        TransformationOptionType = "level"
+	Levels       TransformationOptionType = "levels"
+	Strength     TransformationOptionType = "strength"
+	Divisor      TransformationOptionType = "divisor"
+	Bias         TransformationOptionType = "bias"
+	Radius       TransformationOptionType = "radius"
+	Sigma        TransformationOptionType = "sigma"
+	Amount       TransformationOptionType = "amount"
+	Seed         TransformationOptionType = "seed"
+	Feather      TransformationOptionType = "feather"
+	Frame        TransformationOptionType = "frame"
+	Start        TransformationOptionType = "start"
+	End          TransformationOptionType = "end"
+)
+
+// Parameters hold the non-numeric settings of a transformation, such as a mode or an anchor. Options remain numeric
+// so that the existing transformations keep working unchanged.
+
+type TransformationParameterType string
+
+const (
+	Mode           TransformationParameterType = "mode"
+	Anchor         TransformationParameterType = "anchor"
+	Filter         TransformationParameterType = "filter"
+	Color          TransformationParameterType = "color"
+	Channel        TransformationParameterType = "channel"
+	ShadowColor    TransformationParameterType = "shadow_color"
+	HighlightColor TransformationParameterType = "highlight_color"
+	Regions        TransformationParameterType = "regions"
+)
+
+// Values hold the settings of a transformation that are lists of numbers, such as the points of a curve.
+
+type TransformationValuesType string
+
+const (
+	Points TransformationValuesType = "points"
+	Kernel TransformationValuesType = "kernel"
+)
+
+type ResizeMode string
+
+const (
+	ResizeModeStretch ResizeMode = "stretch"
+	ResizeModeFit     ResizeMode = "fit"
+	ResizeModeFill    ResizeMode = "fill"
+	ResizeModePad     ResizeMode = "pad"
+)
+
+type FilterType string
+
+const (
+	FilterLanczos         FilterType = "lanczos"
+	FilterCatmullRom      FilterType = "catmull_rom"
+	FilterBox             FilterType = "box"
+	FilterNearestNeighbor FilterType = "nearest_neighbor"
+)
+
+type CropMode string
+
+const (
+	CropModeAnchor      CropMode = "anchor"
+	CropModeRectangle   CropMode = "rectangle"
+	CropModeAspectRatio CropMode = "aspect_ratio"
+	CropModePercentage  CropMode = "percentage"
+)
+
+type AnchorType string
+
+const (
+	AnchorCenter      AnchorType = "center"
+	AnchorTopLeft     AnchorType = "top_left"
+	AnchorTop         AnchorType = "top"
+	AnchorTopRight    AnchorType = "top_right"
+	AnchorLeft        AnchorType = "left"
+	AnchorRight       AnchorType = "right"
+	AnchorBottomLeft  AnchorType = "bottom_left"
+	AnchorBottom      AnchorType = "bottom"
+	AnchorBottomRight AnchorType = "bottom_right"
+)
+
+type EdgeDetectionMode string
+
+const (
+	EdgeDetectionModeSobel     EdgeDetectionMode = "sobel"
+	EdgeDetectionModeLaplacian EdgeDetectionMode = "laplacian"
+)
+
+type RedactMode string
+
+const (
+	RedactModePixelate RedactMode = "pixelate"
+	RedactModeBlur     RedactMode = "blur"
+	RedactModeFill     RedactMode = "fill"
+)
+
+type NoiseMode string
+
+const (
+	NoiseModeMonochrome NoiseMode = "monochrome"
+	NoiseModeColor      NoiseMode = "color"
+)
+
+type ChannelType string
+
+const (
+	ChannelAll   ChannelType = "all"
+	ChannelRed   ChannelType = "red"
+	ChannelGreen ChannelType = "green"
+	ChannelBlue  ChannelType = "blue"
 )
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultPreviewVariants = "thumb:150,small:320,medium:640,large:1280"
+	maxPreviewVariantSize  = 4096
+)
+
+// PreviewVariant is a named, downscaled copy of every image that fits into a square of the given size. Variants are
+// never larger than the image itself, so that they can be offered side by side, e.g. in a srcset.
+type PreviewVariant struct {
+	Name string
+	Size int
+}
+
+// ImageVariant describes a preview variant as stored for a particular image.
+type ImageVariant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// ParsePreviewVariants reads the variants from a comma-separated list of name:size pairs, e.g. "thumb:150,small:320".
+func ParsePreviewVariants(value string) ([]PreviewVariant, error) {
+	var variants []PreviewVariant
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		name, sizeValue, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("variant '%s' must be in the name:size format", entry)
+		}
+
+		err := validateVariantName(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("variant '%s' is defined more than once", name)
+		}
+		seen[name] = true
+
+		size, err := strconv.Atoi(sizeValue)
+		if err != nil || size < 1 || size > maxPreviewVariantSize {
+			return nil, fmt.Errorf("size of variant '%s' must be between 1 and %d", name, maxPreviewVariantSize)
+		}
+
+		variants = append(variants, PreviewVariant{Name: name, Size: size})
+	}
+
+	return variants, nil
+}
+
+func validateVariantName(name string) error {
+	if name == "" || len(name) > 32 {
+		return fmt.Errorf("variant name must be between 1 and 32 characters")
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return fmt.Errorf("variant name '%s' can only contain lowercase letters, digits and underscores", name)
+		}
+	}
+
+	return nil
+}
+
+func FindPreviewVariant(variants []PreviewVariant, name string) (PreviewVariant, bool) {
+	for _, variant := range variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+
+	return PreviewVariant{}, false
+}
+
+func CreateVariantImageObjectName(id uuid.UUID, variant string) string {
+	return fmt.Sprintf("var-%s-%s", variant, id)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePreviewVariants(t *testing.T) {
+	type args struct {
+		value string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []PreviewVariant
+		wantErr bool
+	}{
+		{
+			"Default variants",
+			args{value: DefaultPreviewVariants},
+			[]PreviewVariant{{"thumb", 150}, {"small", 320}, {"medium", 640}, {"large", 1280}},
+			false,
+		},
+		{
+			"Spaces around entries",
+			args{value: "a:10, b_2:20"},
+			[]PreviewVariant{{"a", 10}, {"b_2", 20}},
+			false,
+		},
+		{
+			"Missing size",
+			args{value: "thumb"},
+			nil,
+			true,
+		},
+		{
+			"Invalid size",
+			args{value: "thumb:0"},
+			nil,
+			true,
+		},
+		{
+			"Invalid name",
+			args{value: "Thumb:100"},
+			nil,
+			true,
+		},
+		{
+			"Duplicate name",
+			args{value: "thumb:100,thumb:200"},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePreviewVariants(tt.args.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePreviewVariants() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParsePreviewVariants() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,16 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"image-processing-service/src/internal/common/database/tx"
 	"image-processing-service/src/internal/common/metrics"
 	"image-processing-service/src/internal/images/domain"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -17,6 +21,24 @@ type ImagesDBRepository struct {
 	txProvider *tx.Provider
 }
 
+// imageMetadataColumns are the columns read by scanImageMetadata, in the order it reads them.
+const imageMetadataColumns = `id, user_id, name, folder, description, blurhash, lqip, content_hash, ahash, dhash, phash, palette, 
+	format, size, width, height, 
+	ARRAY(SELECT t.name FROM images_tags it JOIN tags t ON t.id = it.tag_id WHERE it.image_id = images_metadata.id ORDER BY t.name), 
+	created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanImageMetadata reads a row starting with the imageMetadataColumns into the image metadata, followed by any extra
+// columns of the row.
+func scanImageMetadata(row scanner, imageMetadata *domain.ImageMetadata, extra ...any) error {
+	dest := []any{&imageMetadata.ID, &imageMetadata.UserID, &imageMetadata.Name, &imageMetadata.Folder, &imageMetadata.Description, &imageMetadata.BlurHash, &imageMetadata.LQIP, &imageMetadata.Hashes.ContentHash, &imageMetadata.Hashes.AHash, &imageMetadata.Hashes.DHash, &imageMetadata.Hashes.PHash, &imageMetadata.Palette, &imageMetadata.Properties.Format, &imageMetadata.Properties.Size, &imageMetadata.Properties.Width, &imageMetadata.Properties.Height, pq.Array(&imageMetadata.Tags), &imageMetadata.CreatedAt, &imageMetadata.UpdatedAt}
+
+	return row.Scan(append(dest, extra...)...)
+}
+
 func NewImagesDBRepository(db *sql.DB, txProvider *tx.Provider) *ImagesDBRepository {
 	return &ImagesDBRepository{db: db, txProvider: txProvider}
 }
@@ -24,22 +46,23 @@ func NewImagesDBRepository(db *sql.DB, txProvider *tx.Provider) *ImagesDBReposit
 func (r *ImagesDBRepository) CreateImageMetadata(
 	ctx context.Context,
 	userID uuid.UUID,
+	folder,
 	name,
 	description string,
 ) (*domain.ImageMetadata, error) {
-	slog.Info("DB query", "operation", "INSERT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, name: %s, description: %s", userID, name, description))
+	slog.Info("DB query", "operation", "INSERT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, folder: %s, name: %s, description: %s", userID, folder, name, description))
 	metrics.DBQueriesTotal.WithLabelValues("INSERT").Inc()
 
-	imageMetadata := domain.NewImageMetadata(userID, name, description)
+	imageMetadata := domain.NewImageMetadata(userID, folder, name, description)
 	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, `INSERT INTO images_metadata (id, user_id, name, description, created_at, updated_at) 
-											VALUES ($1, $2, $3, $4, $5, $6)`,
-			imageMetadata.ID, imageMetadata.UserID, imageMetadata.Name, imageMetadata.Description, imageMetadata.CreatedAt, imageMetadata.UpdatedAt)
+		_, err := tx.ExecContext(ctx, `INSERT INTO images_metadata (id, user_id, name, folder, description, created_at, updated_at) 
+											VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			imageMetadata.ID, imageMetadata.UserID, imageMetadata.Name, imageMetadata.Folder, imageMetadata.Description, imageMetadata.CreatedAt, imageMetadata.UpdatedAt)
 		if err != nil {
 			return fmt.Errorf("error creating image metadata: %w", err)
 		}
 
-		return nil
+		return refreshSearchVectors(ctx, tx, imageMetadata.ID)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error creating image metadata: %w", err)
@@ -48,15 +71,17 @@ func (r *ImagesDBRepository) CreateImageMetadata(
 	return imageMetadata, nil
 }
 
-func (r *ImagesDBRepository) GetImageMetadataByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*domain.ImageMetadata, error) {
-	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, name: %s", userID, name))
+func (r *ImagesDBRepository) GetImageMetadataByUserIDAndPath(ctx context.Context, userID uuid.UUID, path string) (*domain.ImageMetadata, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, path: %s", userID, path))
 	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
 
+	folder, name := domain.SplitImagePath(path)
+
 	var imageMetadata domain.ImageMetadata
-	row := r.db.QueryRowContext(ctx, `SELECT id, user_id, name, description, created_at, updated_at 
+	row := r.db.QueryRowContext(ctx, `SELECT `+imageMetadataColumns+` 
 										FROM images_metadata 
-										WHERE user_id = $1 AND name = $2`, userID, name)
-	err := row.Scan(&imageMetadata.ID, &imageMetadata.UserID, &imageMetadata.Name, &imageMetadata.Description, &imageMetadata.CreatedAt, &imageMetadata.UpdatedAt)
+										WHERE user_id = $1 AND folder = $2 AND name = $3`, userID, folder, name)
+	err := scanImageMetadata(row, &imageMetadata)
 	if err != nil {
 		return nil, fmt.Errorf("error getting image metadata: %w", err)
 	}
@@ -64,20 +89,62 @@ func (r *ImagesDBRepository) GetImageMetadataByUserIDAndName(ctx context.Context
 	return &imageMetadata, nil
 }
 
-func (r *ImagesDBRepository) GetImagesMetadataByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]*domain.ImageMetadata, int, error) {
-	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, page: %d, limit: %d", userID, page, limit))
+func (r *ImagesDBRepository) GetImageMetadataByID(ctx context.Context, id uuid.UUID) (*domain.ImageMetadata, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("id: %s", id))
 	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
 
-	offset := (page - 1) * limit
+	var imageMetadata domain.ImageMetadata
+	row := r.db.QueryRowContext(ctx, `SELECT `+imageMetadataColumns+` 
+										FROM images_metadata 
+										WHERE id = $1`, id)
+	err := scanImageMetadata(row, &imageMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image metadata: %w", err)
+	}
+
+	return &imageMetadata, nil
+}
+
+// ListImagesMetadataByUserID reads a page of the images of the user that match the filter. One more image than the
+// limit is read, so that the caller can tell whether there is another page, and the images are read in reverse for
+// backward cursors.
+func (r *ImagesDBRepository) ListImagesMetadataByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	filter domain.ImagesFilter,
+	listing domain.ImagesListing,
+) ([]*domain.ImageMetadata, int, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, filter: %+v, listing: %+v", userID, filter, listing))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
 
 	var imagesMetadata []*domain.ImageMetadata
 	var total int
 
-	rows, err := r.db.QueryContext(ctx, `SELECT id, user_id, name, description, created_at, updated_at 
+	where, args := buildImagesFilter(userID, filter)
+
+	key, keyType := sortKeys[listing.Sort].expression, sortKeys[listing.Sort].dbType
+	descending := listing.Order == domain.SortOrderDescending
+	if listing.Cursor != nil && listing.Cursor.Backward {
+		descending = !descending
+	}
+	comparison, direction := ">", "ASC"
+	if descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	pageWhere, pageArgs := where, args
+	if listing.Cursor != nil {
+		pageArgs = append(slices.Clone(args), listing.Cursor.Value, listing.Cursor.ID)
+		pageWhere = fmt.Sprintf(`%s AND (%s, id) %s ($%d::%s, $%d)`, where, key, comparison, len(pageArgs)-1, keyType, len(pageArgs))
+	}
+
+	offset := (listing.Page - 1) * listing.Limit
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`SELECT `+imageMetadataColumns+` 
 										FROM images_metadata 
-										WHERE user_id = $1
-										ORDER BY created_at DESC
-										LIMIT $2 OFFSET $3`, userID, limit, offset)
+										WHERE %s
+										ORDER BY %s %s, id %s
+										LIMIT $%d OFFSET $%d`, pageWhere, key, direction, direction, len(pageArgs)+1, len(pageArgs)+2), append(pageArgs, listing.Limit+1, offset)...)
 	if err != nil {
 		return nil, -1, fmt.Errorf("error getting images metadata: %w", err)
 	}
@@ -85,7 +152,7 @@ func (r *ImagesDBRepository) GetImagesMetadataByUserID(ctx context.Context, user
 
 	for rows.Next() {
 		var imageMetadata domain.ImageMetadata
-		err := rows.Scan(&imageMetadata.ID, &imageMetadata.UserID, &imageMetadata.Name, &imageMetadata.Description, &imageMetadata.CreatedAt, &imageMetadata.UpdatedAt)
+		err := scanImageMetadata(rows, &imageMetadata)
 		if err != nil {
 			return nil, -1, fmt.Errorf("error scanning image metadata: %w", err)
 		}
@@ -93,7 +160,7 @@ func (r *ImagesDBRepository) GetImagesMetadataByUserID(ctx context.Context, user
 		imagesMetadata = append(imagesMetadata, &imageMetadata)
 	}
 
-	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM images_metadata WHERE user_id = $1`, userID).Scan(&total)
+	err = r.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM images_metadata WHERE %s`, where), args...).Scan(&total)
 	if err != nil {
 		return nil, -1, fmt.Errorf("error getting total images metadata: %w", err)
 	}
@@ -101,6 +168,227 @@ func (r *ImagesDBRepository) GetImagesMetadataByUserID(ctx context.Context, user
 	return imagesMetadata, total, nil
 }
 
+// sortKeys map the sort orders to the expressions the images are sorted by, along with the type of the expression that
+// the values of cursors are cast to.
+var sortKeys = map[domain.ImagesSort]struct {
+	expression string
+	dbType     string
+}{
+	domain.ImagesSortCreatedAt:  {"created_at", "TIMESTAMP"},
+	domain.ImagesSortUpdatedAt:  {"updated_at", "TIMESTAMP"},
+	domain.ImagesSortName:       {"name", "TEXT"},
+	domain.ImagesSortSize:       {"size", "BIGINT"},
+	domain.ImagesSortDimensions: {"(width::BIGINT * height)", "BIGINT"},
+}
+
+// buildImagesFilter returns the WHERE clause selecting the images of the user that match the filter, along with its
+// arguments. Only placeholders are put into the clause, never the values themselves.
+func buildImagesFilter(userID uuid.UUID, filter domain.ImagesFilter) (string, []any) {
+	conditions := []string{"user_id = $1"}
+	args := []any{userID}
+
+	if filter.Folder != "" {
+		args = append(args, filter.Folder)
+		if filter.Recursive {
+			args = append(args, domain.FolderPrefix(filter.Folder))
+			conditions = append(conditions, fmt.Sprintf("(folder = $%d OR starts_with(folder, $%d))", len(args)-1, len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("folder = $%d", len(args)))
+		}
+	}
+
+	if filter.Color != nil {
+		args = append(args, int(filter.Color.R), int(filter.Color.G), int(filter.Color.B), filter.ColorDistance*filter.ColorDistance)
+		n := len(args)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (SELECT 1 FROM jsonb_array_elements(palette) AS c
+			WHERE ((c->>'r')::int - $%d) * ((c->>'r')::int - $%d)
+				+ ((c->>'g')::int - $%d) * ((c->>'g')::int - $%d)
+				+ ((c->>'b')::int - $%d) * ((c->>'b')::int - $%d) <= $%d)`, n-3, n-3, n-2, n-2, n-1, n-1, n))
+	}
+
+	if len(filter.Tags) > 0 {
+		args = append(args, pq.Array(filter.Tags))
+		tagged := fmt.Sprintf(`SELECT COUNT(*) FROM images_tags it JOIN tags t ON t.id = it.tag_id
+			WHERE it.image_id = images_metadata.id AND t.name = ANY($%d)`, len(args))
+		if filter.TagMatch == domain.TagMatchAll {
+			args = append(args, len(filter.Tags))
+			conditions = append(conditions, fmt.Sprintf(`(%s) = $%d`, tagged, len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf(`(%s) > 0`, tagged))
+		}
+	}
+
+	if filter.CreatedFrom != nil {
+		args = append(args, *filter.CreatedFrom)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.CreatedTo != nil {
+		args = append(args, *filter.CreatedTo)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	if filter.Format != "" {
+		args = append(args, filter.Format)
+		conditions = append(conditions, fmt.Sprintf("format = $%d", len(args)))
+	}
+
+	for _, bound := range []struct {
+		condition string
+		value     int
+	}{
+		{"width >= $%d", filter.MinWidth},
+		{"width <= $%d", filter.MaxWidth},
+		{"height >= $%d", filter.MinHeight},
+		{"height <= $%d", filter.MaxHeight},
+	} {
+		if bound.value > 0 {
+			args = append(args, bound.value)
+			conditions = append(conditions, fmt.Sprintf(bound.condition, len(args)))
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (r *ImagesDBRepository) GetImagesMetadataByUserIDAndContentHash(
+	ctx context.Context,
+	userID uuid.UUID,
+	contentHash string,
+) ([]*domain.ImageMetadata, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, contentHash: %s", userID, contentHash))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var imagesMetadata []*domain.ImageMetadata
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+imageMetadataColumns+`
+										FROM images_metadata
+										WHERE user_id = $1 AND content_hash = $2
+										ORDER BY created_at DESC`, userID, contentHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting images metadata: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var imageMetadata domain.ImageMetadata
+		err := scanImageMetadata(rows, &imageMetadata)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning image metadata: %w", err)
+		}
+
+		imagesMetadata = append(imagesMetadata, &imageMetadata)
+	}
+
+	return imagesMetadata, nil
+}
+
+func (r *ImagesDBRepository) GetImagesMetadataByUserIDAndIDs(
+	ctx context.Context,
+	userID uuid.UUID,
+	ids []uuid.UUID,
+) ([]*domain.ImageMetadata, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, ids: %v", userID, ids))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var imagesMetadata []*domain.ImageMetadata
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+imageMetadataColumns+`
+										FROM images_metadata
+										WHERE user_id = $1 AND id = ANY($2)`, userID, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return nil, fmt.Errorf("error getting images metadata: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var imageMetadata domain.ImageMetadata
+		err := scanImageMetadata(rows, &imageMetadata)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning image metadata: %w", err)
+		}
+
+		imagesMetadata = append(imagesMetadata, &imageMetadata)
+	}
+
+	return imagesMetadata, nil
+}
+
+// GetImagesHashesByUserID reads the hashes of all images of the user that have them, newest first.
+func (r *ImagesDBRepository) GetImagesHashesByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.HashedImage, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s", userID))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, content_hash, ahash, dhash, phash
+										FROM images_metadata
+										WHERE user_id = $1 AND content_hash <> ''
+										ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting images hashes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHashedImages(rows)
+}
+
+// pHashSegmentExpressions extract the segments of the pHash, in the order of domain.Hash.Segments. They must match the
+// expressions of the idx_images_user_id_phash_* indices for the indices to be used.
+var pHashSegmentExpressions = [domain.PHashSegmentCount]string{
+	"((phash >> 48) & 65535)",
+	"((phash >> 32) & 65535)",
+	"((phash >> 16) & 65535)",
+	"(phash & 65535)",
+}
+
+// GetImagesHashesByUserIDAndPHash reads the hashes of the images of the user whose pHash may be within maxDistance of
+// the given one. Only the images sharing a nearby pHash segment are read, so the distances still have to be checked.
+func (r *ImagesDBRepository) GetImagesHashesByUserIDAndPHash(
+	ctx context.Context,
+	userID uuid.UUID,
+	pHash domain.Hash,
+	maxDistance int,
+) ([]*domain.HashedImage, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, pHash: %s, maxDistance: %d", userID, pHash, maxDistance))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	query := `SELECT id, content_hash, ahash, dhash, phash
+				FROM images_metadata
+				WHERE user_id = $1 AND content_hash <> ''`
+	args := []any{userID}
+
+	if candidates, ok := domain.PHashSegmentCandidates(pHash, maxDistance); ok {
+		var conditions []string
+		for i, values := range candidates {
+			args = append(args, pq.Array(values))
+			conditions = append(conditions, fmt.Sprintf("%s = ANY($%d)", pHashSegmentExpressions[i], len(args)))
+		}
+		query += ` AND (` + strings.Join(conditions, " OR ") + `)`
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting images hashes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHashedImages(rows)
+}
+
+// scanHashedImages reads rows of the id, content_hash, ahash, dhash and phash columns.
+func scanHashedImages(rows *sql.Rows) ([]*domain.HashedImage, error) {
+	var images []*domain.HashedImage
+	for rows.Next() {
+		var image domain.HashedImage
+		err := rows.Scan(&image.ID, &image.Hashes.ContentHash, &image.Hashes.AHash, &image.Hashes.DHash, &image.Hashes.PHash)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning image hashes: %w", err)
+		}
+
+		images = append(images, &image)
+	}
+
+	return images, nil
+}
+
 func (r *ImagesDBRepository) GetAllImagesMetadata(ctx context.Context, page, limit int) ([]*domain.ImageMetadata, int, error) {
 	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("page: %d, limit: %d", page, limit))
 	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
@@ -110,7 +398,7 @@ func (r *ImagesDBRepository) GetAllImagesMetadata(ctx context.Context, page, lim
 	var imagesMetadata []*domain.ImageMetadata
 	var total int
 
-	rows, err := r.db.QueryContext(ctx, `SELECT id, user_id, name, description, created_at, updated_at
+	rows, err := r.db.QueryContext(ctx, `SELECT `+imageMetadataColumns+`
 										FROM images_metadata
 										ORDER BY created_at DESC
 										LIMIT $1 OFFSET $2`, limit, offset)
@@ -121,7 +409,7 @@ func (r *ImagesDBRepository) GetAllImagesMetadata(ctx context.Context, page, lim
 
 	for rows.Next() {
 		var imageMetadata domain.ImageMetadata
-		err := rows.Scan(&imageMetadata.ID, &imageMetadata.UserID, &imageMetadata.Name, &imageMetadata.Description, &imageMetadata.CreatedAt, &imageMetadata.UpdatedAt)
+		err := scanImageMetadata(rows, &imageMetadata)
 		if err != nil {
 			return nil, -1, fmt.Errorf("error scanning image metadata: %w", err)
 		}
@@ -149,7 +437,7 @@ func (r *ImagesDBRepository) UpdateImageMetadataDetails(ctx context.Context, id
 			return fmt.Errorf("error updating image metadata details: %w", err)
 		}
 
-		return nil
+		return refreshSearchVectors(ctx, tx, id)
 	})
 	if err != nil {
 		return fmt.Errorf("error updating image metadata details: %w", err)
@@ -177,17 +465,103 @@ func (r *ImagesDBRepository) UpdateImageMetadataUpdatedAt(ctx context.Context, i
 	return nil
 }
 
+func (r *ImagesDBRepository) UpdateImageMetadataPlaceholders(ctx context.Context, id uuid.UUID, blurHash, lqip string) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "images_metadata", "parameters", fmt.Sprintf("id: %s, blurHash: %s", id, blurHash))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE images_metadata SET blurhash = $1, lqip = $2 WHERE id = $3`, blurHash, lqip, id)
+		if err != nil {
+			return fmt.Errorf("error updating image metadata placeholders: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating image metadata placeholders: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImagesDBRepository) UpdateImageMetadataHashes(ctx context.Context, id uuid.UUID, hashes domain.ImageHashes) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "images_metadata", "parameters", fmt.Sprintf("id: %s, contentHash: %s, aHash: %s, dHash: %s, pHash: %s", id, hashes.ContentHash, hashes.AHash, hashes.DHash, hashes.PHash))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE images_metadata 
+										SET content_hash = $1, ahash = $2, dhash = $3, phash = $4 
+										WHERE id = $5`, hashes.ContentHash, hashes.AHash, hashes.DHash, hashes.PHash, id)
+		if err != nil {
+			return fmt.Errorf("error updating image metadata hashes: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating image metadata hashes: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImagesDBRepository) UpdateImageMetadataProperties(ctx context.Context, id uuid.UUID, properties domain.ImageProperties) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "images_metadata", "parameters", fmt.Sprintf("id: %s, properties: %+v", id, properties))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE images_metadata 
+										SET format = $1, size = $2, width = $3, height = $4 
+										WHERE id = $5`, properties.Format, properties.Size, properties.Width, properties.Height, id)
+		if err != nil {
+			return fmt.Errorf("error updating image metadata properties: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating image metadata properties: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImagesDBRepository) UpdateImageMetadataPalette(ctx context.Context, id uuid.UUID, palette domain.Palette) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "images_metadata", "parameters", fmt.Sprintf("id: %s, palette: %v", id, palette))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE images_metadata SET palette = $1 WHERE id = $2`, palette, id)
+		if err != nil {
+			return fmt.Errorf("error updating image metadata palette: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating image metadata palette: %w", err)
+	}
+
+	return nil
+}
+
 func (r *ImagesDBRepository) DeleteImageMetadata(ctx context.Context, id uuid.UUID) error {
 	slog.Info("DB query", "operation", "DELETE", "table", "images_metadata", "parameters", fmt.Sprintf("id: %s", id))
 	metrics.DBQueriesTotal.WithLabelValues("DELETE").Inc()
 
 	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, `DELETE FROM images_metadata WHERE id = $1`, id)
+		var userID uuid.UUID
+		err := tx.QueryRowContext(ctx, `DELETE FROM images_metadata WHERE id = $1 RETURNING user_id`, id).Scan(&userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("error deleting image metadata: %w", err)
 		}
 
-		return nil
+		// The tags of the image are untagged along with it, so the ones it was the last to use are deleted like in
+		// RemoveImageTags.
+		return deleteUnusedTags(ctx, tx, userID)
 	})
 	if err != nil {
 		return fmt.Errorf("error deleting image metadata: %w", err)
@@ -195,3 +569,762 @@ func (r *ImagesDBRepository) DeleteImageMetadata(ctx context.Context, id uuid.UU
 
 	return nil
 }
+
+const jobColumns = `id, user_id, type, status, image_id, source_image_id, source_content_hash, error, created_at, updated_at`
+
+func scanJob(row scanner, job *domain.Job) error {
+	return row.Scan(&job.ID, &job.UserID, &job.Type, &job.Status, &job.ImageID, &job.Source.ImageID, &job.Source.ContentHash, &job.Error, &job.CreatedAt, &job.UpdatedAt)
+}
+
+func (r *ImagesDBRepository) CreateJob(
+	ctx context.Context,
+	userID uuid.UUID,
+	jobType domain.JobType,
+	source domain.JobSource,
+) (*domain.Job, error) {
+	slog.Info("DB query", "operation", "INSERT", "table", "images_jobs", "parameters", fmt.Sprintf("userID: %s, type: %s, source: %+v", userID, jobType, source))
+	metrics.DBQueriesTotal.WithLabelValues("INSERT").Inc()
+
+	job := domain.NewJob(userID, jobType, source)
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO images_jobs (`+jobColumns+`) 
+											VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			job.ID, job.UserID, job.Type, job.Status, job.ImageID, job.Source.ImageID, job.Source.ContentHash, job.Error, job.CreatedAt, job.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("error creating job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *ImagesDBRepository) GetJobByUserIDAndID(ctx context.Context, userID, id uuid.UUID) (*domain.Job, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_jobs", "parameters", fmt.Sprintf("userID: %s, id: %s", userID, id))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var job domain.Job
+	row := r.db.QueryRowContext(ctx, `SELECT `+jobColumns+` 
+										FROM images_jobs 
+										WHERE user_id = $1 AND id = $2`, userID, id)
+	err := scanJob(row, &job)
+	if err != nil {
+		return nil, fmt.Errorf("error getting job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// GetActiveJobByUserIDAndSource returns the latest pending or running job of the type working on the source, or nil if
+// there is none.
+func (r *ImagesDBRepository) GetActiveJobByUserIDAndSource(
+	ctx context.Context,
+	userID uuid.UUID,
+	jobType domain.JobType,
+	source domain.JobSource,
+) (*domain.Job, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_jobs", "parameters", fmt.Sprintf("userID: %s, type: %s, source: %+v", userID, jobType, source))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var job domain.Job
+	row := r.db.QueryRowContext(ctx, `SELECT `+jobColumns+` 
+										FROM images_jobs 
+										WHERE user_id = $1 AND type = $2 AND source_image_id = $3 AND source_content_hash = $4 
+											AND status IN ($5, $6)
+										ORDER BY created_at DESC
+										LIMIT 1`, userID, jobType, source.ImageID, source.ContentHash, domain.JobStatusPending, domain.JobStatusRunning)
+	err := scanJob(row, &job)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting active job: %w", err)
+	}
+
+	return &job, nil
+}
+
+func (r *ImagesDBRepository) UpdateJob(
+	ctx context.Context,
+	id uuid.UUID,
+	status domain.JobStatus,
+	imageID uuid.NullUUID,
+	errorMessage string,
+) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "images_jobs", "parameters", fmt.Sprintf("id: %s, status: %s, imageID: %v, error: %s", id, status, imageID, errorMessage))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE images_jobs 
+										SET status = $1, image_id = $2, error = $3, updated_at = $4 
+										WHERE id = $5`, status, imageID, errorMessage, time.Now(), id)
+		if err != nil {
+			return fmt.Errorf("error updating job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImagesDBRepository) DeleteJob(ctx context.Context, id uuid.UUID) error {
+	slog.Info("DB query", "operation", "DELETE", "table", "images_jobs", "parameters", fmt.Sprintf("id: %s", id))
+	metrics.DBQueriesTotal.WithLabelValues("DELETE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM images_jobs WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("error deleting job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting job: %w", err)
+	}
+
+	return nil
+}
+
+// GetImageAnalysis returns the analysis of the image made for the given content, or nil if there is none.
+func (r *ImagesDBRepository) GetImageAnalysis(ctx context.Context, imageID uuid.UUID, contentHash string) (*domain.ImageAnalysis, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_analyses", "parameters", fmt.Sprintf("imageID: %s, contentHash: %s", imageID, contentHash))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var analysis domain.ImageAnalysis
+	row := r.db.QueryRowContext(ctx, `SELECT analysis 
+										FROM images_analyses 
+										WHERE image_id = $1 AND content_hash = $2`, imageID, contentHash)
+	err := row.Scan(&analysis)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting image analysis: %w", err)
+	}
+
+	return &analysis, nil
+}
+
+// SaveImageAnalysis stores the analysis of the image, replacing the analysis of any previous content.
+func (r *ImagesDBRepository) SaveImageAnalysis(
+	ctx context.Context,
+	imageID uuid.UUID,
+	contentHash string,
+	analysis *domain.ImageAnalysis,
+) error {
+	slog.Info("DB query", "operation", "INSERT", "table", "images_analyses", "parameters", fmt.Sprintf("imageID: %s, contentHash: %s", imageID, contentHash))
+	metrics.DBQueriesTotal.WithLabelValues("INSERT").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO images_analyses (image_id, content_hash, analysis, created_at) 
+										VALUES ($1, $2, $3, $4)
+										ON CONFLICT (image_id) DO UPDATE 
+										SET content_hash = EXCLUDED.content_hash, analysis = EXCLUDED.analysis, created_at = EXCLUDED.created_at`,
+			imageID, contentHash, analysis, time.Now())
+		if err != nil {
+			return fmt.Errorf("error saving image analysis: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error saving image analysis: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImagesDBRepository) AddImageTags(ctx context.Context, userID uuid.UUID, imageIDs []uuid.UUID, tags []string) error {
+	slog.Info("DB query", "operation", "INSERT", "table", "images_tags", "parameters", fmt.Sprintf("userID: %s, imageIDs: %v, tags: %v", userID, imageIDs, tags))
+	metrics.DBQueriesTotal.WithLabelValues("INSERT").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		for _, tag := range tags {
+			_, err := tx.ExecContext(ctx, `INSERT INTO tags (id, user_id, name, created_at) 
+											VALUES ($1, $2, $3, $4)
+											ON CONFLICT (user_id, name) DO NOTHING`, uuid.New(), userID, tag, time.Now())
+			if err != nil {
+				return fmt.Errorf("error creating tag: %w", err)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO images_tags (image_id, tag_id) 
+										SELECT i.id, t.id 
+										FROM images_metadata i, tags t 
+										WHERE i.user_id = $1 AND i.id = ANY($2) AND t.user_id = $1 AND t.name = ANY($3)
+										ON CONFLICT DO NOTHING`, userID, pq.Array(uuidStrings(imageIDs)), pq.Array(tags))
+		if err != nil {
+			return fmt.Errorf("error tagging images: %w", err)
+		}
+
+		return refreshSearchVectors(ctx, tx, imageIDs...)
+	})
+	if err != nil {
+		return fmt.Errorf("error adding image tags: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveImageTags removes the tags from the images. Tags that no image carries anymore are deleted, so the tags of a
+// user are exactly the ones in use.
+func (r *ImagesDBRepository) RemoveImageTags(ctx context.Context, userID uuid.UUID, imageIDs []uuid.UUID, tags []string) error {
+	slog.Info("DB query", "operation", "DELETE", "table", "images_tags", "parameters", fmt.Sprintf("userID: %s, imageIDs: %v, tags: %v", userID, imageIDs, tags))
+	metrics.DBQueriesTotal.WithLabelValues("DELETE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM images_tags 
+										WHERE image_id = ANY($2) 
+										AND tag_id IN (SELECT id FROM tags WHERE user_id = $1 AND name = ANY($3))`,
+			userID, pq.Array(uuidStrings(imageIDs)), pq.Array(tags))
+		if err != nil {
+			return fmt.Errorf("error untagging images: %w", err)
+		}
+
+		err = refreshSearchVectors(ctx, tx, imageIDs...)
+		if err != nil {
+			return err
+		}
+
+		return deleteUnusedTags(ctx, tx, userID)
+	})
+	if err != nil {
+		return fmt.Errorf("error removing image tags: %w", err)
+	}
+
+	return nil
+}
+
+// deleteUnusedTags deletes the tags of the user that no image is tagged with anymore.
+func deleteUnusedTags(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM tags 
+									WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM images_tags WHERE tag_id = tags.id)`, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting unused tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImagesDBRepository) GetTagsByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.TagCount, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "tags", "parameters", fmt.Sprintf("userID: %s", userID))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var tags []*domain.TagCount
+
+	rows, err := r.db.QueryContext(ctx, `SELECT t.name, COUNT(it.image_id) 
+										FROM tags t 
+										LEFT JOIN images_tags it ON it.tag_id = t.id 
+										WHERE t.user_id = $1
+										GROUP BY t.name
+										ORDER BY COUNT(it.image_id) DESC, t.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tag domain.TagCount
+		err := rows.Scan(&tag.Name, &tag.Count)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning tag: %w", err)
+		}
+
+		tags = append(tags, &tag)
+	}
+
+	return tags, nil
+}
+
+func (r *ImagesDBRepository) SearchImagesMetadataByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	query string,
+	page,
+	limit int,
+) ([]*domain.SearchResult, int, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "images_metadata", "parameters", fmt.Sprintf("userID: %s, query: %s, page: %d, limit: %d", userID, query, page, limit))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	offset := (page - 1) * limit
+
+	var results []*domain.SearchResult
+	var total int
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+imageMetadataColumns+`, 
+											ts_rank(search_vector, q), 
+											ts_headline('simple', translate(`+searchNameText+` || ' ' || description || ' ' || `+searchTagsText+`, 
+												'`+domain.SnippetMatchStart+domain.SnippetMatchEnd+`', ''), q, 
+												'StartSel=`+domain.SnippetMatchStart+`, StopSel=`+domain.SnippetMatchEnd+`, MaxFragments=2') 
+										FROM images_metadata, to_tsquery('simple', $2) AS q 
+										WHERE user_id = $1 AND search_vector @@ q
+										ORDER BY ts_rank(search_vector, q) DESC, created_at DESC
+										LIMIT $3 OFFSET $4`, userID, query, limit, offset)
+	if err != nil {
+		return nil, -1, fmt.Errorf("error searching images metadata: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var imageMetadata domain.ImageMetadata
+		result := domain.SearchResult{Image: &imageMetadata}
+		err := scanImageMetadata(rows, &imageMetadata, &result.Rank, &result.Snippet)
+		if err != nil {
+			return nil, -1, fmt.Errorf("error scanning image metadata: %w", err)
+		}
+		result.Snippet = domain.NewSnippet(result.Snippet)
+
+		results = append(results, &result)
+	}
+
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) 
+										FROM images_metadata 
+										WHERE user_id = $1 AND search_vector @@ to_tsquery('simple', $2)`, userID, query).Scan(&total)
+	if err != nil {
+		return nil, -1, fmt.Errorf("error getting total images metadata: %w", err)
+	}
+
+	return results, total, nil
+}
+
+const albumColumns = `a.id, a.user_id, a.name, a.description, a.cover_image_id, 
+	COALESCE(rtrim(c.folder, '/') || '/' || c.name, 
+		(SELECT rtrim(i.folder, '/') || '/' || i.name FROM albums_images ai JOIN images_metadata i ON i.id = ai.image_id 
+		WHERE ai.album_id = a.id ORDER BY ai.position, ai.image_id LIMIT 1), ''), 
+	(SELECT COUNT(*) FROM albums_images ai WHERE ai.album_id = a.id), 
+	a.created_at, a.updated_at`
+
+func scanAlbum(row scanner, album *domain.Album) error {
+	return row.Scan(&album.ID, &album.UserID, &album.Name, &album.Description, &album.CoverImageID, &album.Cover, &album.ImageCount, &album.CreatedAt, &album.UpdatedAt)
+}
+
+func (r *ImagesDBRepository) CreateAlbum(ctx context.Context, userID uuid.UUID, name, description string) (*domain.Album, error) {
+	slog.Info("DB query", "operation", "INSERT", "table", "albums", "parameters", fmt.Sprintf("userID: %s, name: %s, description: %s", userID, name, description))
+	metrics.DBQueriesTotal.WithLabelValues("INSERT").Inc()
+
+	album := domain.NewAlbum(userID, name, description)
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO albums (id, user_id, name, description, created_at, updated_at) 
+										VALUES ($1, $2, $3, $4, $5, $6)`,
+			album.ID, album.UserID, album.Name, album.Description, album.CreatedAt, album.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("error creating album: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating album: %w", err)
+	}
+
+	return album, nil
+}
+
+func (r *ImagesDBRepository) GetAlbumByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*domain.Album, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "albums", "parameters", fmt.Sprintf("userID: %s, name: %s", userID, name))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var album domain.Album
+	row := r.db.QueryRowContext(ctx, `SELECT `+albumColumns+` 
+										FROM albums a 
+										LEFT JOIN images_metadata c ON c.id = a.cover_image_id 
+										WHERE a.user_id = $1 AND a.name = $2`, userID, name)
+	err := scanAlbum(row, &album)
+	if err != nil {
+		return nil, fmt.Errorf("error getting album: %w", err)
+	}
+
+	return &album, nil
+}
+
+func (r *ImagesDBRepository) GetAlbumsByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Album, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "albums", "parameters", fmt.Sprintf("userID: %s", userID))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var albums []*domain.Album
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+albumColumns+` 
+										FROM albums a 
+										LEFT JOIN images_metadata c ON c.id = a.cover_image_id 
+										WHERE a.user_id = $1
+										ORDER BY a.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting albums: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var album domain.Album
+		err := scanAlbum(rows, &album)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning album: %w", err)
+		}
+
+		albums = append(albums, &album)
+	}
+
+	return albums, nil
+}
+
+func (r *ImagesDBRepository) UpdateAlbumDetails(
+	ctx context.Context,
+	id uuid.UUID,
+	newName,
+	newDescription string,
+	newCoverImageID uuid.NullUUID,
+) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "albums", "parameters", fmt.Sprintf("id: %s, newName: %s, newDescription: %s, newCoverImageID: %v", id, newName, newDescription, newCoverImageID))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE albums 
+										SET name = $1, description = $2, cover_image_id = $3, updated_at = $4 
+										WHERE id = $5`, newName, newDescription, newCoverImageID, time.Now(), id)
+		if err != nil {
+			return fmt.Errorf("error updating album details: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error updating album details: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAlbum deletes the album along with its membership entries, but not the images in it.
+func (r *ImagesDBRepository) DeleteAlbum(ctx context.Context, id uuid.UUID) error {
+	slog.Info("DB query", "operation", "DELETE", "table", "albums", "parameters", fmt.Sprintf("id: %s", id))
+	metrics.DBQueriesTotal.WithLabelValues("DELETE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM albums WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("error deleting album: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting album: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImagesDBRepository) GetAlbumImagesMetadata(
+	ctx context.Context,
+	albumID uuid.UUID,
+	page,
+	limit int,
+) ([]*domain.ImageMetadata, int, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "albums_images", "parameters", fmt.Sprintf("albumID: %s, page: %d, limit: %d", albumID, page, limit))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	offset := (page - 1) * limit
+
+	var imagesMetadata []*domain.ImageMetadata
+	var total int
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+imageMetadataColumns+` 
+										FROM images_metadata 
+										JOIN albums_images ai ON ai.image_id = images_metadata.id 
+										WHERE ai.album_id = $1
+										ORDER BY ai.position, ai.image_id
+										LIMIT $2 OFFSET $3`, albumID, limit, offset)
+	if err != nil {
+		return nil, -1, fmt.Errorf("error getting album images metadata: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var imageMetadata domain.ImageMetadata
+		err := scanImageMetadata(rows, &imageMetadata)
+		if err != nil {
+			return nil, -1, fmt.Errorf("error scanning image metadata: %w", err)
+		}
+
+		imagesMetadata = append(imagesMetadata, &imageMetadata)
+	}
+
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM albums_images WHERE album_id = $1`, albumID).Scan(&total)
+	if err != nil {
+		return nil, -1, fmt.Errorf("error getting total album images: %w", err)
+	}
+
+	return imagesMetadata, total, nil
+}
+
+// AddAlbumImages appends the images to the end of the album in the given order. Images already in the album keep their
+// position.
+func (r *ImagesDBRepository) AddAlbumImages(ctx context.Context, albumID uuid.UUID, imageIDs []uuid.UUID) error {
+	slog.Info("DB query", "operation", "INSERT", "table", "albums_images", "parameters", fmt.Sprintf("albumID: %s, imageIDs: %v", albumID, imageIDs))
+	metrics.DBQueriesTotal.WithLabelValues("INSERT").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO albums_images (album_id, image_id, position) 
+										SELECT $1, new.image_id, 
+											(SELECT COALESCE(MAX(position), 0) FROM albums_images WHERE album_id = $1) + new.ordinality 
+										FROM unnest($2::UUID[]) WITH ORDINALITY AS new(image_id, ordinality)
+										ON CONFLICT DO NOTHING`, albumID, pq.Array(uuidStrings(imageIDs)))
+		if err != nil {
+			return fmt.Errorf("error adding images to album: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE albums SET updated_at = $1 WHERE id = $2`, time.Now(), albumID)
+		if err != nil {
+			return fmt.Errorf("error updating album: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error adding album images: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveAlbumImages removes the images from the album, along with the choice of the cover if it is one of them.
+func (r *ImagesDBRepository) RemoveAlbumImages(ctx context.Context, albumID uuid.UUID, imageIDs []uuid.UUID) error {
+	slog.Info("DB query", "operation", "DELETE", "table", "albums_images", "parameters", fmt.Sprintf("albumID: %s, imageIDs: %v", albumID, imageIDs))
+	metrics.DBQueriesTotal.WithLabelValues("DELETE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM albums_images WHERE album_id = $1 AND image_id = ANY($2)`,
+			albumID, pq.Array(uuidStrings(imageIDs)))
+		if err != nil {
+			return fmt.Errorf("error removing images from album: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE albums 
+										SET cover_image_id = CASE WHEN cover_image_id = ANY($1) THEN NULL ELSE cover_image_id END, 
+											updated_at = $2 
+										WHERE id = $3`, pq.Array(uuidStrings(imageIDs)), time.Now(), albumID)
+		if err != nil {
+			return fmt.Errorf("error updating album: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error removing album images: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderAlbumImages numbers the images of the album in the given order, which is expected to contain all of them.
+func (r *ImagesDBRepository) ReorderAlbumImages(ctx context.Context, albumID uuid.UUID, imageIDs []uuid.UUID) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "albums_images", "parameters", fmt.Sprintf("albumID: %s, imageIDs: %v", albumID, imageIDs))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE albums_images 
+										SET position = new.ordinality 
+										FROM unnest($2::UUID[]) WITH ORDINALITY AS new(image_id, ordinality) 
+										WHERE albums_images.album_id = $1 AND albums_images.image_id = new.image_id`,
+			albumID, pq.Array(uuidStrings(imageIDs)))
+		if err != nil {
+			return fmt.Errorf("error reordering album images: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE albums SET updated_at = $1 WHERE id = $2`, time.Now(), albumID)
+		if err != nil {
+			return fmt.Errorf("error updating album: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error reordering album images: %w", err)
+	}
+
+	return nil
+}
+
+// folderColumns counts the images directly in the folder and the images anywhere below it.
+const folderColumns = `f.id, f.user_id, f.path, 
+	(SELECT COUNT(*) FROM images_metadata i WHERE i.user_id = f.user_id AND i.folder = f.path), 
+	(SELECT COUNT(*) FROM images_metadata i WHERE i.user_id = f.user_id AND (i.folder = f.path OR starts_with(i.folder, f.path || '/'))), 
+	f.created_at, f.updated_at`
+
+func scanFolder(row scanner, folder *domain.Folder) error {
+	return row.Scan(&folder.ID, &folder.UserID, &folder.Path, &folder.ImageCount, &folder.TotalImageCount, &folder.CreatedAt, &folder.UpdatedAt)
+}
+
+// CreateFolder creates the folder along with any missing folders above it.
+func (r *ImagesDBRepository) CreateFolder(ctx context.Context, userID uuid.UUID, path string) error {
+	slog.Info("DB query", "operation", "INSERT", "table", "folders", "parameters", fmt.Sprintf("userID: %s, path: %s", userID, path))
+	metrics.DBQueriesTotal.WithLabelValues("INSERT").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		return createFolders(ctx, tx, userID, path)
+	})
+	if err != nil {
+		return fmt.Errorf("error creating folder: %w", err)
+	}
+
+	return nil
+}
+
+// createFolders creates the folder and the folders above it that do not exist yet.
+func createFolders(ctx context.Context, tx *sql.Tx, userID uuid.UUID, path string) error {
+	for _, ancestor := range domain.FolderAncestors(path) {
+		folder := domain.NewFolder(userID, ancestor)
+		_, err := tx.ExecContext(ctx, `INSERT INTO folders (id, user_id, path, created_at, updated_at) 
+										VALUES ($1, $2, $3, $4, $5)
+										ON CONFLICT (user_id, path) DO NOTHING`, folder.ID, folder.UserID, folder.Path, folder.CreatedAt, folder.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("error creating folder %s: %w", ancestor, err)
+		}
+	}
+
+	return nil
+}
+
+// GetFolderByUserIDAndPath returns nil if the user has no folder at the path.
+func (r *ImagesDBRepository) GetFolderByUserIDAndPath(ctx context.Context, userID uuid.UUID, path string) (*domain.Folder, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "folders", "parameters", fmt.Sprintf("userID: %s, path: %s", userID, path))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var folder domain.Folder
+	row := r.db.QueryRowContext(ctx, `SELECT `+folderColumns+` 
+										FROM folders f 
+										WHERE f.user_id = $1 AND f.path = $2`, userID, path)
+	err := scanFolder(row, &folder)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting folder: %w", err)
+	}
+
+	return &folder, nil
+}
+
+// GetFoldersByUserID returns the folders directly in the folder at the path, or all folders below it if recursive is
+// set, in the order of their paths.
+func (r *ImagesDBRepository) GetFoldersByUserID(ctx context.Context, userID uuid.UUID, path string, recursive bool) ([]*domain.Folder, error) {
+	slog.Info("DB query", "operation", "SELECT", "table", "folders", "parameters", fmt.Sprintf("userID: %s, path: %s, recursive: %t", userID, path, recursive))
+	metrics.DBQueriesTotal.WithLabelValues("SELECT").Inc()
+
+	var folders []*domain.Folder
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+folderColumns+` 
+										FROM folders f 
+										WHERE f.user_id = $1 AND starts_with(f.path, $2) 
+										AND ($3 OR strpos(substr(f.path, length($2) + 1), '/') = 0)
+										ORDER BY f.path`, userID, domain.FolderPrefix(path), recursive)
+	if err != nil {
+		return nil, fmt.Errorf("error getting folders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var folder domain.Folder
+		err := scanFolder(rows, &folder)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning folder: %w", err)
+		}
+
+		folders = append(folders, &folder)
+	}
+
+	return folders, nil
+}
+
+// MoveFolder changes the path of the folder and, with it, the paths of its subfolders and images. Any missing folders
+// above the new path are created.
+func (r *ImagesDBRepository) MoveFolder(ctx context.Context, userID uuid.UUID, oldPath, newPath string) error {
+	slog.Info("DB query", "operation", "UPDATE", "table", "folders", "parameters", fmt.Sprintf("userID: %s, oldPath: %s, newPath: %s", userID, oldPath, newPath))
+	metrics.DBQueriesTotal.WithLabelValues("UPDATE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE folders 
+										SET path = $3 || substr(path, length($2) + 1), updated_at = $4 
+										WHERE user_id = $1 AND (path = $2 OR starts_with(path, $2 || '/'))`, userID, oldPath, newPath, time.Now())
+		if err != nil {
+			return fmt.Errorf("error moving folders: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE images_metadata 
+										SET folder = $3 || substr(folder, length($2) + 1) 
+										WHERE user_id = $1 AND (folder = $2 OR starts_with(folder, $2 || '/'))`, userID, oldPath, newPath)
+		if err != nil {
+			return fmt.Errorf("error moving images: %w", err)
+		}
+
+		return createFolders(ctx, tx, userID, newPath)
+	})
+	if err != nil {
+		return fmt.Errorf("error moving folder: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFolder deletes the folder along with its subfolders. The folders are expected to contain no images.
+func (r *ImagesDBRepository) DeleteFolder(ctx context.Context, userID uuid.UUID, path string) error {
+	slog.Info("DB query", "operation", "DELETE", "table", "folders", "parameters", fmt.Sprintf("userID: %s, path: %s", userID, path))
+	metrics.DBQueriesTotal.WithLabelValues("DELETE").Inc()
+
+	err := r.txProvider.Transact(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM folders 
+										WHERE user_id = $1 AND (path = $2 OR starts_with(path, $2 || '/'))`, userID, path)
+		if err != nil {
+			return fmt.Errorf("error deleting folders: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting folder: %w", err)
+	}
+
+	return nil
+}
+
+// The texts of the names and tags that are searched. The separators of names are replaced by spaces, as the parser
+// would otherwise take e.g. "banner.png" for a single word.
+const (
+	searchNameText = `regexp_replace(images_metadata.name, '[._/-]', ' ', 'g')`
+	searchTagsText = `coalesce((SELECT string_agg(t.name, ' ') 
+						FROM images_tags it JOIN tags t ON t.id = it.tag_id 
+						WHERE it.image_id = images_metadata.id), '')`
+)
+
+// refreshSearchVectors rebuilds the text searched for the images out of their names, descriptions and tags, weighted in
+// that order. The simple configuration is used, as names and tags are not words that stemming would help with.
+func refreshSearchVectors(ctx context.Context, tx *sql.Tx, imageIDs ...uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `UPDATE images_metadata 
+									SET search_vector = 
+										setweight(to_tsvector('simple', `+searchNameText+`), 'A') || 
+										setweight(to_tsvector('simple', description), 'B') || 
+										setweight(to_tsvector('simple', `+searchTagsText+`), 'C') 
+									WHERE id = ANY($1)`, pq.Array(uuidStrings(imageIDs)))
+	if err != nil {
+		return fmt.Errorf("error refreshing search vectors: %w", err)
+	}
+
+	return nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	return values
+}
@@ -34,12 +34,12 @@ func (r *ImagesStorageRepository) DownloadImage(ctx context.Context, name string
 	metrics.StorageOperationsTotal.WithLabelValues("download").Inc()
 
 	var data = make([]byte, domain.MaxImageSize)
-	_, err := r.storage.Client().DownloadBuffer(ctx, r.storage.ContainerName(), name, data, nil)
+	size, err := r.storage.Client().DownloadBuffer(ctx, r.storage.ContainerName(), name, data, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error downloading file: %w", err)
 	}
 
-	return data, nil
+	return data[:size], nil
 }
 
 func (r *ImagesStorageRepository) DeleteImage(ctx context.Context, name string) error {
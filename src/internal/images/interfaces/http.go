@@ -12,7 +12,9 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -25,6 +27,16 @@ func NewAPI(imagesService *application.ImagesService) *ImageAPI {
 }
 
 func (a *ImageAPI) Upload(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseWarning struct {
+		Type     string `json:"type"`
+		Image    string `json:"image"`
+		Distance int    `json:"distance"`
+	}
+
+	type response struct {
+		Warnings []responseWarning `json:"warnings,omitempty"`
+	}
+
 	name := r.FormValue("name")
 	description := r.FormValue("description")
 
@@ -50,14 +62,23 @@ func (a *ImageAPI) Upload(userID uuid.UUID, w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = a.ImagesService.Upload(userID, name, description, bytes)
+	similarImages, err := a.ImagesService.Upload(userID, name, description, bytes)
 	if err != nil {
 		slog.Error("HTTP request error", "error", err)
 		respond.WithError(w, err)
 		return
 	}
 
-	respond.WithoutContent(w, http.StatusCreated)
+	var warnings []responseWarning
+	for _, similar := range similarImages {
+		warning := responseWarning{Type: "near_duplicate", Image: similar.Image.Path(), Distance: similar.Distance}
+		if similar.Exact {
+			warning.Type = "duplicate"
+		}
+		warnings = append(warnings, warning)
+	}
+
+	respond.WithJSON(w, http.StatusCreated, response{Warnings: warnings})
 }
 
 func (a *ImageAPI) Get(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
@@ -65,54 +86,813 @@ func (a *ImageAPI) Get(userID uuid.UUID, w http.ResponseWriter, r *http.Request)
 		Name string `json:"name"`
 	}
 
-	type responseImageMetadata struct {
+	type responseImageMetadata struct {
+		Name        string                 `json:"name"`
+		Path        string                 `json:"path"`
+		Description string                 `json:"description"`
+		BlurHash    string                 `json:"blurhash"`
+		LQIP        string                 `json:"lqip"`
+		Palette     []responsePaletteColor `json:"palette"`
+		Tags        []string               `json:"tags"`
+		Format      string                 `json:"format"`
+		Size        int64                  `json:"size"`
+		Width       int                    `json:"width"`
+		Height      int                    `json:"height"`
+		UpdatedAt   string                 `json:"updated_at"`
+		CreatedAt   string                 `json:"created_at"`
+	}
+
+	type response struct {
+		Metadata responseImageMetadata `json:"metadata"`
+		Image    string                `json:"image"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	metadata, bytes, err := a.ImagesService.Get(userID, p.Name)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	encodedImage := base64.StdEncoding.EncodeToString(bytes)
+	imageMetadata := responseImageMetadata{
+		Name:        metadata.Name,
+		Path:        metadata.Path(),
+		Description: metadata.Description,
+		BlurHash:    metadata.BlurHash,
+		LQIP:        metadata.LQIP,
+		Palette:     newResponsePalette(metadata.Palette),
+		Tags:        metadata.Tags,
+		Format:      metadata.Properties.Format,
+		Size:        metadata.Properties.Size,
+		Width:       metadata.Properties.Width,
+		Height:      metadata.Properties.Height,
+		UpdatedAt:   metadata.UpdatedAt.String(),
+		CreatedAt:   metadata.CreatedAt.String(),
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{
+		Metadata: imageMetadata,
+		Image:    encodedImage,
+	})
+}
+
+func (a *ImageAPI) GetAll(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseImageMetadata struct {
+		Name        string                 `json:"name"`
+		Path        string                 `json:"path"`
+		Description string                 `json:"description"`
+		BlurHash    string                 `json:"blurhash"`
+		LQIP        string                 `json:"lqip"`
+		Palette     []responsePaletteColor `json:"palette"`
+		Tags        []string               `json:"tags"`
+		Format      string                 `json:"format"`
+		Size        int64                  `json:"size"`
+		Width       int                    `json:"width"`
+		Height      int                    `json:"height"`
+		UpdatedAt   string                 `json:"updated_at"`
+		CreatedAt   string                 `json:"created_at"`
+	}
+
+	type responseImage struct {
+		Metadata     responseImageMetadata `json:"metadata"`
+		ImagePreview string                `json:"image_preview"`
+	}
+
+	type response struct {
+		Images     []responseImage `json:"images"`
+		TotalCount int             `json:"total_count"`
+		Page       int             `json:"page"`
+		Limit      int             `json:"limit"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+		PrevCursor string          `json:"prev_cursor,omitempty"`
+	}
+
+	query := r.URL.Query()
+
+	listing := domain.ImagesListing{
+		Sort:  domain.ImagesSortCreatedAt,
+		Order: domain.SortOrderDescending,
+		Page:  1,
+		Limit: domain.DefaultListLimit,
+	}
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := domain.DecodeCursor(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput(fmt.Sprintf("invalid cursor: %v", err)))
+			return
+		}
+
+		// The cursor carries its order, so that following it does not require repeating the order.
+		listing.Cursor = cursor
+		listing.Sort, listing.Order = cursor.Sort, cursor.Order
+	}
+	if value := query.Get("sort"); value != "" {
+		listing.Sort = domain.ImagesSort(value)
+	}
+	if value := query.Get("order"); value != "" {
+		listing.Order = domain.SortOrder(value)
+	}
+	if value := query.Get("page"); value != "" {
+		var err error
+		listing.Page, err = strconv.Atoi(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid page"))
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		var err error
+		listing.Limit, err = strconv.Atoi(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid limit"))
+			return
+		}
+	}
+
+	var filter domain.ImagesFilter
+	if value := query.Get("color"); value != "" {
+		color, err := domain.ParseHexColor(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput(fmt.Sprintf("invalid color: %v", err)))
+			return
+		}
+
+		filter.Color = &color
+		filter.ColorDistance = domain.DefaultColorDistance
+		if value := query.Get("color_distance"); value != "" {
+			filter.ColorDistance, err = strconv.Atoi(value)
+			if err != nil {
+				slog.Error("HTTP request error", "error", err)
+				respond.WithError(w, commonerrors.NewInvalidInput("invalid color distance"))
+				return
+			}
+		}
+	}
+
+	if value := query.Get("tags"); value != "" {
+		filter.Tags = strings.Split(value, ",")
+		filter.TagMatch = domain.TagMatchAny
+		if value := query.Get("tag_match"); value != "" {
+			filter.TagMatch = domain.TagMatch(value)
+		}
+	}
+
+	for _, date := range []struct {
+		name   string
+		target **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+	} {
+		if value := query.Get(date.name); value != "" {
+			t, err := parseDate(value)
+			if err != nil {
+				slog.Error("HTTP request error", "error", err)
+				respond.WithError(w, commonerrors.NewInvalidInput(fmt.Sprintf("invalid %s", strings.ReplaceAll(date.name, "_", " "))))
+				return
+			}
+			*date.target = &t
+		}
+	}
+
+	filter.Folder = query.Get("folder")
+	filter.Recursive = query.Get("recursive") == "true"
+
+	filter.Format = query.Get("format")
+
+	for _, dimension := range []struct {
+		name   string
+		target *int
+	}{
+		{"min_width", &filter.MinWidth},
+		{"max_width", &filter.MaxWidth},
+		{"min_height", &filter.MinHeight},
+		{"max_height", &filter.MaxHeight},
+	} {
+		if value := query.Get(dimension.name); value != "" {
+			var err error
+			*dimension.target, err = strconv.Atoi(value)
+			if err != nil {
+				slog.Error("HTTP request error", "error", err)
+				respond.WithError(w, commonerrors.NewInvalidInput(fmt.Sprintf("invalid %s", strings.ReplaceAll(dimension.name, "_", " "))))
+				return
+			}
+		}
+	}
+
+	metadata, previews, totalCount, next, prev, err := a.ImagesService.GetAll(userID, filter, listing)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	var respImages []responseImage
+	for i, m := range metadata {
+		encodedPreview := base64.StdEncoding.EncodeToString(previews[i])
+		respImages = append(respImages, responseImage{
+			Metadata: responseImageMetadata{
+				Name:        m.Name,
+				Path:        m.Path(),
+				Description: m.Description,
+				BlurHash:    m.BlurHash,
+				LQIP:        m.LQIP,
+				Palette:     newResponsePalette(m.Palette),
+				Tags:        m.Tags,
+				Format:      m.Properties.Format,
+				Size:        m.Properties.Size,
+				Width:       m.Properties.Width,
+				Height:      m.Properties.Height,
+				UpdatedAt:   m.UpdatedAt.String(),
+				CreatedAt:   m.CreatedAt.String(),
+			},
+			ImagePreview: encodedPreview,
+		})
+	}
+
+	resp := response{Images: respImages, TotalCount: totalCount, Page: listing.Page, Limit: listing.Limit}
+	if next != nil {
+		resp.NextCursor = next.Encode()
+	}
+	if prev != nil {
+		resp.PrevCursor = prev.Encode()
+	}
+
+	respond.WithJSON(w, http.StatusOK, resp)
+}
+
+func (a *ImageAPI) Search(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseImageMetadata struct {
+		Name        string                 `json:"name"`
+		Path        string                 `json:"path"`
+		Description string                 `json:"description"`
+		BlurHash    string                 `json:"blurhash"`
+		LQIP        string                 `json:"lqip"`
+		Palette     []responsePaletteColor `json:"palette"`
+		Tags        []string               `json:"tags"`
+		Format      string                 `json:"format"`
+		Size        int64                  `json:"size"`
+		Width       int                    `json:"width"`
+		Height      int                    `json:"height"`
+		UpdatedAt   string                 `json:"updated_at"`
+		CreatedAt   string                 `json:"created_at"`
+	}
+
+	type responseImage struct {
+		Metadata     responseImageMetadata `json:"metadata"`
+		ImagePreview string                `json:"image_preview"`
+		Rank         float64               `json:"rank"`
+		Snippet      string                `json:"snippet"`
+	}
+
+	type response struct {
+		Images     []responseImage `json:"images"`
+		TotalCount int             `json:"total_count"`
+		Page       int             `json:"page"`
+		Limit      int             `json:"limit"`
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid page"))
+		return
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid limit"))
+		return
+	}
+
+	results, previews, totalCount, err := a.ImagesService.Search(userID, r.URL.Query().Get("q"), page, limit)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	var respImages []responseImage
+	for i, result := range results {
+		m := result.Image
+		respImages = append(respImages, responseImage{
+			Metadata: responseImageMetadata{
+				Name:        m.Name,
+				Path:        m.Path(),
+				Description: m.Description,
+				BlurHash:    m.BlurHash,
+				LQIP:        m.LQIP,
+				Palette:     newResponsePalette(m.Palette),
+				Tags:        m.Tags,
+				Format:      m.Properties.Format,
+				Size:        m.Properties.Size,
+				Width:       m.Properties.Width,
+				Height:      m.Properties.Height,
+				UpdatedAt:   m.UpdatedAt.String(),
+				CreatedAt:   m.CreatedAt.String(),
+			},
+			ImagePreview: base64.StdEncoding.EncodeToString(previews[i]),
+			Rank:         result.Rank,
+			Snippet:      result.Snippet,
+		})
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{Images: respImages, TotalCount: totalCount, Page: page, Limit: limit})
+}
+
+func (a *ImageAPI) GetVariants(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseVariant struct {
+		Name   string `json:"name"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+		URL    string `json:"url"`
+	}
+
+	type response struct {
+		Variants []responseVariant `json:"variants"`
+		SrcSet   string            `json:"srcset"`
+	}
+
+	name := r.PathValue("name")
+	variants, err := a.ImagesService.GetVariants(userID, name)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	var respVariants []responseVariant
+	var srcSet []string
+	for _, v := range variants {
+		variantURL := fmt.Sprintf("/images/%s/variants/%s", url.PathEscape(name), v.Name)
+		respVariants = append(respVariants, responseVariant{
+			Name:   v.Name,
+			Width:  v.Width,
+			Height: v.Height,
+			URL:    variantURL,
+		})
+		srcSet = append(srcSet, fmt.Sprintf("%s %dw", variantURL, v.Width))
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{Variants: respVariants, SrcSet: strings.Join(srcSet, ", ")})
+}
+
+func (a *ImageAPI) GetVariant(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	bytes, err := a.ImagesService.GetVariant(userID, r.PathValue("name"), r.PathValue("variant"))
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithBytes(w, http.StatusOK, bytes)
+}
+
+func (a *ImageAPI) GetAnalysis(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		Image    string                `json:"image"`
+		Analysis *domain.ImageAnalysis `json:"analysis"`
+	}
+
+	type jobResponse struct {
+		JobID string `json:"job_id"`
+	}
+
+	name := r.PathValue("name")
+	analysis, jobID, err := a.ImagesService.GetAnalysis(userID, name)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	if analysis == nil {
+		respond.WithJSON(w, http.StatusAccepted, jobResponse{JobID: jobID.String()})
+		return
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{Image: name, Analysis: analysis})
+}
+
+func (a *ImageAPI) GetDuplicates(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseImage struct {
+		Name        string `json:"name"`
+		Path        string `json:"path"`
+		Description string `json:"description"`
+		ContentHash string `json:"content_hash"`
+		PHash       string `json:"phash"`
+	}
+
+	type response struct {
+		Groups      [][]responseImage `json:"groups"`
+		MaxDistance int               `json:"max_distance"`
+	}
+
+	maxDistance := domain.DefaultSimilarityDistance
+	if value := r.URL.Query().Get("max_distance"); value != "" {
+		var err error
+		maxDistance, err = strconv.Atoi(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid max distance"))
+			return
+		}
+	}
+
+	groups, err := a.ImagesService.GetDuplicates(userID, maxDistance)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respGroups := make([][]responseImage, 0, len(groups))
+	for _, group := range groups {
+		var respGroup []responseImage
+		for _, m := range group {
+			respGroup = append(respGroup, responseImage{
+				Name:        m.Name,
+				Path:        m.Path(),
+				Description: m.Description,
+				ContentHash: m.Hashes.ContentHash,
+				PHash:       m.Hashes.PHash.String(),
+			})
+		}
+		respGroups = append(respGroups, respGroup)
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{Groups: respGroups, MaxDistance: maxDistance})
+}
+
+func (a *ImageAPI) SearchSimilar(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseImage struct {
+		Name        string `json:"name"`
+		Path        string `json:"path"`
+		Description string `json:"description"`
+		Distance    int    `json:"distance"`
+		Exact       bool   `json:"exact"`
+	}
+
+	type response struct {
+		Images []responseImage `json:"images"`
+	}
+
+	err := r.ParseMultipartForm(domain.MaxImageSize)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput(fmt.Sprintf("image size exceeds %d bytes", domain.MaxImageSize)))
+		return
+	}
+
+	maxDistance := domain.DefaultSimilarityDistance
+	if value := r.FormValue("max_distance"); value != "" {
+		maxDistance, err = strconv.Atoi(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid max distance"))
+			return
+		}
+	}
+
+	limit := domain.MaxSimilarImagesLimit
+	if value := r.FormValue("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid limit"))
+			return
+		}
+	}
+
+	var bytes []byte
+	file, _, err := r.FormFile("image")
+	if err == nil {
+		defer file.Close()
+
+		bytes, err = io.ReadAll(file)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid image file"))
+			return
+		}
+	}
+
+	similarImages, err := a.ImagesService.SearchSimilar(userID, r.FormValue("name"), bytes, maxDistance, limit)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respImages := make([]responseImage, 0, len(similarImages))
+	for _, similar := range similarImages {
+		respImages = append(respImages, responseImage{
+			Name:        similar.Image.Name,
+			Path:        similar.Image.Path(),
+			Description: similar.Image.Description,
+			Distance:    similar.Distance,
+			Exact:       similar.Exact,
+		})
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{Images: respImages})
+}
+
+func (a *ImageAPI) Diff(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		Image             string   `json:"image"`
+		Width             int      `json:"width"`
+		Height            int      `json:"height"`
+		PSNR              *float64 `json:"psnr"`
+		SSIM              float64  `json:"ssim"`
+		ChangedPercentage float64  `json:"changed_percentage"`
+	}
+
+	err := r.ParseMultipartForm(2 * domain.MaxImageSize)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput(fmt.Sprintf("image size exceeds %d bytes", domain.MaxImageSize)))
+		return
+	}
+
+	threshold := 0
+	if value := r.FormValue("threshold"); value != "" {
+		threshold, err = strconv.Atoi(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid threshold"))
+			return
+		}
+	}
+
+	resize := false
+	if value := r.FormValue("resize"); value != "" {
+		resize, err = strconv.ParseBool(value)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid resize flag"))
+			return
+		}
+	}
+
+	var sources [2]domain.DiffSource
+	for i, field := range []string{"first", "second"} {
+		sources[i].Name = r.FormValue(field)
+		sources[i].Variant = r.FormValue(field + "_variant")
+
+		file, _, err := r.FormFile(field + "_image")
+		if err != nil {
+			continue
+		}
+		defer file.Close()
+
+		sources[i].Bytes, err = io.ReadAll(file)
+		if err != nil {
+			slog.Error("HTTP request error", "error", err)
+			respond.WithError(w, commonerrors.NewInvalidInput("invalid image file"))
+			return
+		}
+	}
+
+	bytes, metrics, err := a.ImagesService.Diff(userID, sources[0], sources[1], resize, threshold)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{
+		Image:             base64.StdEncoding.EncodeToString(bytes),
+		Width:             metrics.Width,
+		Height:            metrics.Height,
+		PSNR:              metrics.PSNR,
+		SSIM:              metrics.SSIM,
+		ChangedPercentage: metrics.ChangedPercentage,
+	})
+}
+
+func (a *ImageAPI) UpdateDetails(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		OldName        string `json:"old_name"`
+		NewName        string `json:"new_name"`
+		NewDescription string `json:"new_description"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	err = a.ImagesService.UpdateDetails(userID, p.OldName, p.NewName, p.NewDescription)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusNoContent)
+}
+
+func (a *ImageAPI) AddTags(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	a.tag(userID, w, r, false, a.ImagesService.AddTags)
+}
+
+func (a *ImageAPI) RemoveTags(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	a.tag(userID, w, r, false, a.ImagesService.RemoveTags)
+}
+
+func (a *ImageAPI) AddTagsInBulk(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	a.tag(userID, w, r, true, a.ImagesService.AddTags)
+}
+
+func (a *ImageAPI) RemoveTagsInBulk(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	a.tag(userID, w, r, true, a.ImagesService.RemoveTags)
+}
+
+// tag handles the tagging requests, which tag either the image named in the path or, in bulk, the images listed in the
+// body.
+func (a *ImageAPI) tag(
+	userID uuid.UUID,
+	w http.ResponseWriter,
+	r *http.Request,
+	bulk bool,
+	apply func(userID uuid.UUID, imageNames, tags []string) error,
+) {
+	type parameters struct {
+		Images []string `json:"images"`
+		Tags   []string `json:"tags"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	if !bulk {
+		p.Images = []string{r.PathValue("name")}
+	}
+
+	err = apply(userID, p.Images, p.Tags)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusNoContent)
+}
+
+func (a *ImageAPI) GetTags(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseTag struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	type response struct {
+		Tags []responseTag `json:"tags"`
+	}
+
+	tags, err := a.ImagesService.GetTags(userID)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respTags := make([]responseTag, 0, len(tags))
+	for _, tag := range tags {
+		respTags = append(respTags, responseTag{Name: tag.Name, Count: tag.Count})
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{Tags: respTags})
+}
+
+func (a *ImageAPI) CreateAlbum(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	err = a.ImagesService.CreateAlbum(userID, p.Name, p.Description)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusCreated)
+}
+
+func (a *ImageAPI) GetAlbum(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Cover       string `json:"cover"`
+		ImageCount  int    `json:"image_count"`
+		UpdatedAt   string `json:"updated_at"`
+		CreatedAt   string `json:"created_at"`
+	}
+
+	album, err := a.ImagesService.GetAlbum(userID, r.PathValue("name"))
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{
+		Name:        album.Name,
+		Description: album.Description,
+		Cover:       album.Cover,
+		ImageCount:  album.ImageCount,
+		UpdatedAt:   album.UpdatedAt.String(),
+		CreatedAt:   album.CreatedAt.String(),
+	})
+}
+
+func (a *ImageAPI) GetAlbums(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseAlbum struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
+		Cover       string `json:"cover"`
+		ImageCount  int    `json:"image_count"`
 		UpdatedAt   string `json:"updated_at"`
 		CreatedAt   string `json:"created_at"`
 	}
 
 	type response struct {
-		Metadata responseImageMetadata `json:"metadata"`
-		Image    string                `json:"image"`
-	}
-
-	var p parameters
-	decoder := json.NewDecoder(r.Body)
-	err := decoder.Decode(&p)
-	if err != nil {
-		slog.Error("HTTP request error", "error", err)
-		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
-		return
+		Albums []responseAlbum `json:"albums"`
 	}
 
-	metadata, bytes, err := a.ImagesService.Get(userID, p.Name)
+	albums, err := a.ImagesService.GetAlbums(userID)
 	if err != nil {
 		slog.Error("HTTP request error", "error", err)
 		respond.WithError(w, err)
 		return
 	}
 
-	encodedImage := base64.StdEncoding.EncodeToString(bytes)
-	imageMetadata := responseImageMetadata{
-		Name:        metadata.Name,
-		Description: metadata.Description,
-		UpdatedAt:   metadata.UpdatedAt.String(),
-		CreatedAt:   metadata.CreatedAt.String(),
+	respAlbums := make([]responseAlbum, 0, len(albums))
+	for _, album := range albums {
+		respAlbums = append(respAlbums, responseAlbum{
+			Name:        album.Name,
+			Description: album.Description,
+			Cover:       album.Cover,
+			ImageCount:  album.ImageCount,
+			UpdatedAt:   album.UpdatedAt.String(),
+			CreatedAt:   album.CreatedAt.String(),
+		})
 	}
 
-	respond.WithJSON(w, http.StatusOK, response{
-		Metadata: imageMetadata,
-		Image:    encodedImage,
-	})
+	respond.WithJSON(w, http.StatusOK, response{Albums: respAlbums})
 }
 
-func (a *ImageAPI) GetAll(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+func (a *ImageAPI) GetAlbumImages(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
 	type responseImageMetadata struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		UpdatedAt   string `json:"updated_at"`
-		CreatedAt   string `json:"created_at"`
+		Name        string                 `json:"name"`
+		Path        string                 `json:"path"`
+		Description string                 `json:"description"`
+		BlurHash    string                 `json:"blurhash"`
+		LQIP        string                 `json:"lqip"`
+		Palette     []responsePaletteColor `json:"palette"`
+		Tags        []string               `json:"tags"`
+		Format      string                 `json:"format"`
+		Size        int64                  `json:"size"`
+		Width       int                    `json:"width"`
+		Height      int                    `json:"height"`
+		UpdatedAt   string                 `json:"updated_at"`
+		CreatedAt   string                 `json:"created_at"`
 	}
 
 	type responseImage struct {
@@ -141,7 +921,7 @@ func (a *ImageAPI) GetAll(userID uuid.UUID, w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	metadata, previews, totalCount, err := a.ImagesService.GetAll(userID, page, limit)
+	metadata, previews, totalCount, err := a.ImagesService.GetAlbumImages(userID, r.PathValue("name"), page, limit)
 	if err != nil {
 		slog.Error("HTTP request error", "error", err)
 		respond.WithError(w, err)
@@ -150,26 +930,34 @@ func (a *ImageAPI) GetAll(userID uuid.UUID, w http.ResponseWriter, r *http.Reque
 
 	var respImages []responseImage
 	for i, m := range metadata {
-		encodedPreview := base64.StdEncoding.EncodeToString(previews[i])
 		respImages = append(respImages, responseImage{
 			Metadata: responseImageMetadata{
 				Name:        m.Name,
+				Path:        m.Path(),
 				Description: m.Description,
+				BlurHash:    m.BlurHash,
+				LQIP:        m.LQIP,
+				Palette:     newResponsePalette(m.Palette),
+				Tags:        m.Tags,
+				Format:      m.Properties.Format,
+				Size:        m.Properties.Size,
+				Width:       m.Properties.Width,
+				Height:      m.Properties.Height,
 				UpdatedAt:   m.UpdatedAt.String(),
 				CreatedAt:   m.CreatedAt.String(),
 			},
-			ImagePreview: encodedPreview,
+			ImagePreview: base64.StdEncoding.EncodeToString(previews[i]),
 		})
 	}
 
 	respond.WithJSON(w, http.StatusOK, response{Images: respImages, TotalCount: totalCount, Page: page, Limit: limit})
 }
 
-func (a *ImageAPI) UpdateDetails(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+func (a *ImageAPI) UpdateAlbum(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
 	type parameters struct {
-		OldName        string `json:"old_name"`
 		NewName        string `json:"new_name"`
 		NewDescription string `json:"new_description"`
+		NewCover       string `json:"new_cover"`
 	}
 
 	var p parameters
@@ -181,7 +969,173 @@ func (a *ImageAPI) UpdateDetails(userID uuid.UUID, w http.ResponseWriter, r *htt
 		return
 	}
 
-	err = a.ImagesService.UpdateDetails(userID, p.OldName, p.NewName, p.NewDescription)
+	err = a.ImagesService.UpdateAlbum(userID, r.PathValue("name"), p.NewName, p.NewDescription, p.NewCover)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusNoContent)
+}
+
+func (a *ImageAPI) DeleteAlbum(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	err := a.ImagesService.DeleteAlbum(userID, r.PathValue("name"))
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusNoContent)
+}
+
+func (a *ImageAPI) AddAlbumImages(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	a.changeAlbumImages(userID, w, r, a.ImagesService.AddAlbumImages)
+}
+
+func (a *ImageAPI) RemoveAlbumImages(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	a.changeAlbumImages(userID, w, r, a.ImagesService.RemoveAlbumImages)
+}
+
+func (a *ImageAPI) ReorderAlbumImages(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	a.changeAlbumImages(userID, w, r, a.ImagesService.ReorderAlbumImages)
+}
+
+// changeAlbumImages handles the requests that change the images of the album named in the path with the images listed
+// in the body.
+func (a *ImageAPI) changeAlbumImages(
+	userID uuid.UUID,
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(userID uuid.UUID, name string, imageNames []string) error,
+) {
+	type parameters struct {
+		Images []string `json:"images"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	err = apply(userID, r.PathValue("name"), p.Images)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusNoContent)
+}
+
+func (a *ImageAPI) CreateFolder(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Path string `json:"path"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	err = a.ImagesService.CreateFolder(userID, p.Path)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusCreated)
+}
+
+func (a *ImageAPI) GetFolders(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type responseFolder struct {
+		Path            string `json:"path"`
+		ImageCount      int    `json:"image_count"`
+		TotalImageCount int    `json:"total_image_count"`
+		UpdatedAt       string `json:"updated_at"`
+		CreatedAt       string `json:"created_at"`
+	}
+
+	type response struct {
+		Folders []responseFolder `json:"folders"`
+	}
+
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		path = domain.RootFolder
+	}
+
+	folders, err := a.ImagesService.GetFolders(userID, path, r.URL.Query().Get("recursive") == "true")
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respFolders := make([]responseFolder, 0, len(folders))
+	for _, folder := range folders {
+		respFolders = append(respFolders, responseFolder{
+			Path:            folder.Path,
+			ImageCount:      folder.ImageCount,
+			TotalImageCount: folder.TotalImageCount,
+			UpdatedAt:       folder.UpdatedAt.String(),
+			CreatedAt:       folder.CreatedAt.String(),
+		})
+	}
+
+	respond.WithJSON(w, http.StatusOK, response{Folders: respFolders})
+}
+
+func (a *ImageAPI) MoveFolder(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		OldPath string `json:"old_path"`
+		NewPath string `json:"new_path"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	err = a.ImagesService.MoveFolder(userID, p.OldPath, p.NewPath)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusNoContent)
+}
+
+func (a *ImageAPI) DeleteFolder(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Path string `json:"path"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	err = a.ImagesService.DeleteFolder(userID, p.Path)
 	if err != nil {
 		slog.Error("HTTP request error", "error", err)
 		respond.WithError(w, err)
@@ -216,6 +1170,173 @@ func (a *ImageAPI) Transform(userID uuid.UUID, w http.ResponseWriter, r *http.Re
 	respond.WithoutContent(w, http.StatusNoContent)
 }
 
+func (a *ImageAPI) Composite(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parametersLayer struct {
+		Name      string   `json:"name"`
+		X         int      `json:"x"`
+		Y         int      `json:"y"`
+		Opacity   *float64 `json:"opacity"`
+		BlendMode string   `json:"blend_mode"`
+	}
+
+	type parameters struct {
+		Name        string            `json:"name"`
+		Description string            `json:"description"`
+		Layout      string            `json:"layout"`
+		Columns     int               `json:"columns"`
+		Spacing     int               `json:"spacing"`
+		Width       int               `json:"width"`
+		Height      int               `json:"height"`
+		Background  string            `json:"background"`
+		Images      []parametersLayer `json:"images"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	composition := domain.Composition{
+		Layout:     domain.CompositionLayout(p.Layout),
+		Columns:    p.Columns,
+		Spacing:    p.Spacing,
+		Width:      p.Width,
+		Height:     p.Height,
+		Background: p.Background,
+	}
+	for _, l := range p.Images {
+		opacity := 1.0
+		if l.Opacity != nil {
+			opacity = *l.Opacity
+		}
+		composition.Layers = append(composition.Layers, domain.CompositionLayer{
+			Name:      l.Name,
+			X:         l.X,
+			Y:         l.Y,
+			Opacity:   opacity,
+			BlendMode: domain.BlendMode(l.BlendMode),
+		})
+	}
+
+	err = a.ImagesService.Composite(userID, p.Name, p.Description, composition)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithoutContent(w, http.StatusCreated)
+}
+
+func (a *ImageAPI) CreateSpriteSheet(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Images      []string `json:"images"`
+		Padding     int      `json:"padding"`
+		MaxSize     int      `json:"max_size"`
+	}
+
+	type response struct {
+		JobID string `json:"job_id"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	jobID, err := a.ImagesService.CreateSpriteSheet(userID, p.Name, p.Description, p.Images, p.Padding, p.MaxSize)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithJSON(w, http.StatusAccepted, response{JobID: jobID.String()})
+}
+
+func (a *ImageAPI) CreateContactSheet(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Images      []string `json:"images"`
+		Columns     int      `json:"columns"`
+	}
+
+	type response struct {
+		JobID string `json:"job_id"`
+	}
+
+	var p parameters
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&p)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid body"))
+		return
+	}
+
+	jobID, err := a.ImagesService.CreateContactSheet(userID, p.Name, p.Description, p.Images, p.Columns)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	respond.WithJSON(w, http.StatusAccepted, response{JobID: jobID.String()})
+}
+
+func (a *ImageAPI) GetJob(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		ID        string          `json:"id"`
+		Type      string          `json:"type"`
+		Status    string          `json:"status"`
+		Error     string          `json:"error,omitempty"`
+		Image     string          `json:"image,omitempty"`
+		Document  json.RawMessage `json:"document,omitempty"`
+		CreatedAt string          `json:"created_at"`
+		UpdatedAt string          `json:"updated_at"`
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, commonerrors.NewInvalidInput("invalid job ID"))
+		return
+	}
+
+	job, imageMetadata, document, err := a.ImagesService.GetJob(userID, id)
+	if err != nil {
+		slog.Error("HTTP request error", "error", err)
+		respond.WithError(w, err)
+		return
+	}
+
+	resp := response{
+		ID:        job.ID.String(),
+		Type:      string(job.Type),
+		Status:    string(job.Status),
+		Error:     job.Error,
+		Document:  document,
+		CreatedAt: job.CreatedAt.String(),
+		UpdatedAt: job.UpdatedAt.String(),
+	}
+	if imageMetadata != nil {
+		resp.Image = imageMetadata.Path()
+	}
+
+	respond.WithJSON(w, http.StatusOK, resp)
+}
+
 func (a *ImageAPI) Delete(userID uuid.UUID, w http.ResponseWriter, r *http.Request) {
 	type parameters struct {
 		Name string `json:"name"`
@@ -243,6 +1364,7 @@ func (a *ImageAPI) Delete(userID uuid.UUID, w http.ResponseWriter, r *http.Reque
 func (a *ImageAPI) AdminListAllImages(w http.ResponseWriter, r *http.Request) {
 	type responseImage struct {
 		Name        string    `json:"name"`
+		Path        string    `json:"path"`
 		Description string    `json:"description"`
 		CreatedAt   time.Time `json:"created_at"`
 		UpdatedAt   time.Time `json:"updated_at"`
@@ -280,6 +1402,7 @@ func (a *ImageAPI) AdminListAllImages(w http.ResponseWriter, r *http.Request) {
 	for _, img := range images {
 		responseImages = append(responseImages, responseImage{
 			Name:      img.Name,
+			Path:      img.Path(),
 			CreatedAt: img.CreatedAt,
 			UpdatedAt: img.UpdatedAt,
 		})
@@ -310,3 +1433,28 @@ func (a *ImageAPI) AdminDeleteImage(w http.ResponseWriter, r *http.Request) {
 
 	respond.WithoutContent(w, http.StatusNoContent)
 }
+
+type responsePaletteColor struct {
+	Color      string  `json:"color"`
+	Proportion float64 `json:"proportion"`
+}
+
+func newResponsePalette(palette domain.Palette) []responsePaletteColor {
+	respPalette := make([]responsePaletteColor, len(palette))
+	for i, c := range palette {
+		respPalette[i] = responsePaletteColor{Color: c.Hex(), Proportion: c.Proportion}
+	}
+
+	return respPalette
+}
+
+// parseDate accepts either a full timestamp or a date, which stands for the start of the day. The timestamps of images
+// are stored in the local time of the server, so the result is in local time as well.
+func parseDate(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t.Local(), nil
+	}
+
+	return time.ParseInLocation(time.DateOnly, value, time.Local)
+}